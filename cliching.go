@@ -1,77 +1,22 @@
 package main
 
 import (
-	"encoding/json"
+	"bufio"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"io/fs"
 	"math/rand"
 	"os"
-	"regexp"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
-)
-
-// Hexagram holds data parsed from JSON file
-type Hexagram struct {
-	ID    int       `json:"id"`
-	Lines [6]string `json:"lines"`
-	Name  string    `json:"name"`
-	Desc  string    `json:"desc"`
-}
-
-// Hexagrams holds hexagrams parsed from JSON file
-type Hexagrams struct {
-	Hexagrams []Hexagram `json:"hexagrams"`
-}
-
-func findHexagram(a [6]string, b [6]string) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	for i, v := range a {
-		if v != b[i] {
-			return false
-		}
-	}
-	return true
-}
-
-func generateHexagram(coins bool) [6]string {
-	var freshHexagram [6]string
+	"unicode/utf8"
 
-	if coins {
-		for i := 0; i < len(freshHexagram); i++ {
-			c1 := rand.Intn(4-2) + 2
-			c2 := rand.Intn(4-2) + 2
-			c3 := rand.Intn(4-2) + 2
-			sum := c1 + c2 + c3
-
-			if sum == 6 {
-				freshHexagram[i] = "--- X ---"
-			} else if sum == 9 {
-				freshHexagram[i] = "----O----"
-			} else if sum == 7 {
-				freshHexagram[i] = "---------"
-			} else {
-				freshHexagram[i] = "---   ---"
-			}
-		}
-		return freshHexagram
-	}
-
-	marbles := [16]string{
-		"--- X ---",
-		"----O----", "----O----", "----O----",
-		"---------", "---------", "---------", "---------", "---------",
-		"---   ---", "---   ---", "---   ---", "---   ---", "---   ---",
-		"---   ---", "---   ---"}
-
-	for i := 0; i < len(freshHexagram); i++ {
-		line := rand.Intn(len(marbles))
-		freshHexagram[i] = marbles[line]
-	}
-	return freshHexagram
-}
+	"github.com/tyybbi/cliching/iching"
+)
 
 func wordWrap(text string, lineWidth int) string {
 	words := strings.Fields(strings.TrimSpace(text))
@@ -93,40 +38,240 @@ func wordWrap(text string, lineWidth int) string {
 	return wrapped
 }
 
-func findHxgrmManually(find string) [6]string {
-	var re = regexp.MustCompile(`[xy]{6}`)
-	var shape [6]string
+// centre indents text to sit centred under a figure; text too wide for
+// that is wrapped and left aligned with the figure instead
+func centre(text string) string {
+	const indent, width = "    ", 9
 
-	if !re.MatchString(find) || len(find) != 6 {
-		flag.Usage()
-		os.Exit(1)
-	} else {
-		for i := range find {
-			if string(find[i]) == "x" {
-				shape[i] = "---------"
-			} else {
-				shape[i] = "---   ---"
-			}
-		}
+	if n := utf8.RuneCountInString(text); n < width {
+		return indent + strings.Repeat(" ", (width-n)/2) + text
 	}
-	return shape
+	return indent + strings.ReplaceAll(wordWrap(text, 22), "\n", "\n"+indent)
 }
 
-func printer(hexagram Hexagram, title string, quiet bool) {
+func printer(hexagram iching.Hexagram, title string, quiet, chinese bool) {
 	fmt.Println(title)
 	for i := range hexagram.Lines {
 		fmt.Printf("    %s", hexagram.Lines[len(hexagram.Lines)-1-i])
 		fmt.Println()
 	}
 	fmt.Printf("        %v\n", hexagram.ID)
-	fmt.Printf("    %v\n", hexagram.Name)
+	fmt.Println(centre(hexagram.Name))
+	if chinese {
+		fmt.Printf("    %s %s %s\n", hexagram.Symbol, hexagram.Chinese, hexagram.Pinyin)
+	}
+	fmt.Println()
+	upper, lower := hexagram.Upper(), hexagram.Lower()
+	fmt.Printf("    %s: %s %s, %s (%s)\n", tr("Upper"), upper.Name, upper.Chinese, tr(upper.Image), tr(upper.Attribute))
+	fmt.Printf("    %s: %s %s, %s (%s)\n", tr("Lower"), lower.Name, lower.Chinese, tr(lower.Image), tr(lower.Attribute))
 	fmt.Println()
 	if !quiet {
-		fmt.Println(wordWrap(hexagram.Desc, 35))
+		if hexagram.Desc != "" {
+			fmt.Println(wordWrap(hexagram.Desc, 35))
+			fmt.Println()
+		}
+		if hexagram.Judgement != "" {
+			fmt.Println("  " + tr("Judgement"))
+			fmt.Println(wordWrap(hexagram.Judgement, 35))
+			fmt.Println()
+		}
+		if hexagram.Image != "" {
+			fmt.Println("  " + tr("Image"))
+			fmt.Println(wordWrap(hexagram.Image, 35))
+			fmt.Println()
+		}
+	}
+}
+
+func printChanging(reading iching.Reading, quiet bool) {
+	if quiet || !reading.Changing {
+		return
+	}
+	header := false
+	for _, pos := range reading.ChangingLines() {
+		if reading.Primary.Texts[pos-1] == "" {
+			continue
+		}
+		if !header {
+			fmt.Println("  " + tr("Changing Lines"))
+			header = true
+		}
+		text := fmt.Sprintf(tr("Line %d: %s"), pos, reading.Primary.Texts[pos-1])
+		fmt.Println(wordWrap(text, 35))
 		fmt.Println()
 	}
 }
 
+// textColumns lays out the texts of a hexagram in two translations as
+// lines for two columns, each section starting on the same line on both
+// sides so that like is set beside like
+func textColumns(lname string, lhex iching.Hexagram, rname string, rhex iching.Hexagram) (left, right []string) {
+	left = []string{"  " + lname, "", lhex.Name, ""}
+	right = []string{"  " + rname, "", rhex.Name, ""}
+	add := func(title, ltext, rtext string) {
+		if ltext == "" && rtext == "" {
+			return
+		}
+		section := func(text string) []string {
+			if text == "" {
+				return nil
+			}
+			var lines []string
+			if title != "" {
+				lines = append(lines, "  "+title)
+			}
+			return append(lines, strings.Split(wordWrap(text, 35), "\n")...)
+		}
+		l, r := section(ltext), section(rtext)
+		for len(l) < len(r) {
+			l = append(l, "")
+		}
+		for len(r) < len(l) {
+			r = append(r, "")
+		}
+		left = append(append(left, l...), "")
+		right = append(append(right, r...), "")
+	}
+	add("", lhex.Desc, rhex.Desc)
+	add(tr("Judgement"), lhex.Judgement, rhex.Judgement)
+	add(tr("Image"), lhex.Image, rhex.Image)
+	return left, right
+}
+
+func printSideBySide(left, right []string) {
+	for i := 0; i < len(left) || i < len(right); i++ {
+		var l, r string
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		fmt.Println(strings.TrimRight(padRight(l, 37)+"  "+r, " "))
+	}
+}
+
+func printNuclear(h iching.Hexagrams, hexagram iching.Hexagram, title string, quiet, chinese bool) {
+	nhex, err := h.Nuclear(hexagram)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, trError(err))
+		os.Exit(1)
+	}
+	printer(nhex, title, quiet, chinese)
+}
+
+func printRelations(h iching.Hexagrams, hexagram iching.Hexagram) {
+	ihex, err := h.Inverse(hexagram)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, trError(err))
+		os.Exit(1)
+	}
+	chex, err := h.Complement(hexagram)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, trError(err))
+		os.Exit(1)
+	}
+	fmt.Printf("    %s%2d %s\n", padRight(tr("Inverse")+":", 15), ihex.ID, ihex.Name)
+	fmt.Printf("    %s%2d %s\n", padRight(tr("Complementary")+":", 15), chex.ID, chex.Name)
+	fmt.Println()
+}
+
+func addNuclear(h iching.Hexagrams, doc *readingDoc, hexagram iching.Hexagram) {
+	nhex, err := h.Nuclear(hexagram)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, trError(err))
+		os.Exit(1)
+	}
+	nuclear := newHexagramDoc(nhex)
+	doc.Nuclear = &nuclear
+}
+
+func addRelations(h iching.Hexagrams, doc *readingDoc, hexagram iching.Hexagram) {
+	ihex, err := h.Inverse(hexagram)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, trError(err))
+		os.Exit(1)
+	}
+	chex, err := h.Complement(hexagram)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, trError(err))
+		os.Exit(1)
+	}
+	inverse, complement := newHexagramDoc(ihex), newHexagramDoc(chex)
+	doc.Inverse, doc.Complement = &inverse, &complement
+}
+
+func writeDoc(render func(io.Writer, readingDoc) error, doc readingDoc) {
+	if err := render(os.Stdout, doc); err != nil {
+		fmt.Fprintln(os.Stderr, trError(err))
+		os.Exit(1)
+	}
+}
+
+func outputFormats() []string {
+	formats := []string{"text"}
+	for name := range renderers {
+		formats = append(formats, name)
+	}
+	sort.Strings(formats[1:])
+	return formats
+}
+
+func readTosses(in io.Reader, out io.Writer) ([6]iching.Line, error) {
+	var lines [6]iching.Line
+	scanner := bufio.NewScanner(in)
+
+	for i := 0; i < len(lines); {
+		fmt.Fprintf(out, tr("Line %d, three coins (h/t): "), i+1)
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return lines, err
+			}
+			return lines, io.ErrUnexpectedEOF
+		}
+		l, err := iching.TossLine(scanner.Text())
+		if err != nil {
+			fmt.Fprintln(out, trError(err))
+			continue
+		}
+		lines[i] = l
+		i++
+	}
+	fmt.Fprintln(out)
+	return lines, nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	layouts := []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf(tr("invalid time %q: want a form like \"2006-01-02 15:04\""), s)
+}
+
+// loadHexagrams reads the hexagram set from path if given, else the
+// named built-in translation if given, else from the user's config
+// directory, falling back to the default built-in set
+func loadHexagrams(path, translation string) (iching.Hexagrams, error) {
+	if path != "" {
+		return iching.LoadFile(path)
+	}
+	if translation != "" {
+		return iching.LocalTranslation(translation, lang)
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return iching.LocalTranslation(iching.DefaultTranslation, lang)
+	}
+	h, err := iching.LoadFile(filepath.Join(dir, "cliching", "hexagrams.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return iching.LocalTranslation(iching.DefaultTranslation, lang)
+	}
+	return h, err
+}
+
 func isFlagPassed(flg string) bool {
 	found := false
 	flag.Visit(func(f *flag.Flag) {
@@ -138,432 +283,301 @@ func isFlagPassed(flg string) bool {
 }
 
 func main() {
-	var jsonData = []byte(`
-	{
-	    "hexagrams": [ {
-	        "id":   1,
-	        "lines": ["---------", "---------",  "---------",  "---------", "---------",  "---------"],
-	        "name": " Force",
-	        "desc": "Strength, creative energy, action; the power of heaven to create and destroy; dynamic, untiring, tenacious, enduring."
-	        }, {
-	        "id":   2,
-	        "lines": ["---   ---", "---   ---", "---   ---", "---   ---", "---   ---", "---   ---"],
-	        "name": " Field",
-	        "desc": "Yield, nourish, provide; the power to give form to all things; receptive, gentle, giving, supple;\nwelcome, consent."
-	        }, {
-	        "id":   3,
-	        "lines": ["---------", "---   ---",  "---   ---",  "---   ---", "---------", "---   ---"],
-	        "name": "Sprouting",
-	        "desc": "Beginning of growth and its problems; gather your strength; establish, found, assemble."
-	        }, {
-	        "id":   4,
-	        "lines": ["---   ---",  "---------", "---   ---",  "---   ---", "---   ---",  "---------"],
-	        "name": "Enveloping",
-	        "desc": "Immature, young, unaware; concealed, hidden; nurture hidden growth, apprenticeship."
-	        }, {
-	        "id":   5,
-	        "lines": ["---------", "---------", "---------", "---   ---", "---------", "---   ---"],
-	        "name": "Attending",
-	        "desc": "Wait for, wait on; attend to what is needed; watch for the right moment; participant in a sacrifice."
-	        }, {
-	        "id":   6,
-	        "lines": ["---   ---",  "---------", "---   ---",  "---------", "---------", "---------"],
-	        "name": "Arguing",
-	        "desc": "Dispute, controversy, argument; express your position; resolve or retreat from conflict."
-	        }, {
-	        "id":   7,
-	        "lines": ["---   ---",  "---------", "---   ---",  "---   ---", "---   ---",  "---   ---"],
-	        "name": "Legions",
-	        "desc": "Discipline, organize into functional units, mobilize, lead; master of arms."
-	        }, {
-	        "id":   8,
-	        "lines": ["---   ---",  "---   ---",  "---   ---",  "---   ---", "---------", "---   ---"],
-	        "name": "Grouping",
-	        "desc": "Alliance, mutual support, spiritual kin; how you group things and people; changing groups."
-	        }, {
-	        "id":   9,
-	        "lines": ["---------", "---------", "---------", "---   ---", "---------", "---------"],
-	        "name": "Small Accumulating",
-	        "desc": "Accumulate small things to do something great; adapt to each thing that crosses your path; nurture, tame, support, collect."
-	        }, {
-	        "id":   10,
-	        "lines": ["---------", "---------", "---   ---",  "---------", "---------", "---------"],
-	        "name": "Treading",
-	        "desc": "Find and make your way, step by step; conduct, manners, salary, support."
-	        }, {
-	        "id":   11,
-	        "lines": ["---------", "---------", "---------", "---   ---", "---   ---", "---   ---"],
-	        "name": "Pervading",
-	        "desc": "Prospering, expanding, great abundance and harmony; peace, communication; spring, flowering."
-	        }, {
-	        "id":   12,
-	        "lines": ["---   ---",  "---   ---",  "---   ---",  "---------", "---------", "---------"],
-	        "name": "Obstruction",
-	        "desc": "Obstacle, blocked communication; decline, cut off, closed; late autumn."
-	        }, {
-	        "id":   13,
-	        "lines": ["---------", "---   ---",  "---------", "---------", "---------", "---------"],
-	        "name": "Concording People",
-	        "desc": "Harmony, bring people together, share your idea or goal, welcome others, co-operate."
-	        }, {
-	        "id":   14,
-	        "lines": ["---------", "---------", "---------", "---------", "---   ---",  "---------"],
-	        "name": "Great Possessions",
-	        "desc": "A powerful idea; great power to realize things; organize your efforts, concentrate; great results and achievements."
-	        }, {
-	        "id":   15,
-	        "lines": ["---   ---", "---   ---", "---------", "---   ---", "---   ---", "---   ---"],
-	        "name": "Humbling",
-	        "desc": "Cut through pride and complications, keep close to fundamental things; be simple; think and speak of yourself humbly."
-	        }, {
-	        "id":   16,
-	        "lines": ["---   ---",  "---   ---",  "---   ---",  "---------", "---   ---",  "---   ---"],
-	        "name": "Providing For",
-	        "desc": "Gather what you need to meet the future; able to respond immediately; enjoy, pleasure, enthusiasm, be carried away."
-	        }, {
-	        "id":   17,
-	        "lines": ["---------", "---   ---",  "---   ---",  "---------", "---------", "---   ---"],
-	        "name": "Following",
-	        "desc": "Be drawn into motion; influenced by, accept guidance; move with the flow, natural and correct."
-	        }, {
-	        "id":   18,
-	        "lines": ["---   ---",  "---------", "---------", "---   ---", "---   ---",  "---------"],
-	        "name": "Corruption",
-	        "desc": "Disorder, perversion or decay with roots in the past, black magic; renew, renovate, find a new beginning."
-	        }, {
-	        "id":   19,
-	        "lines": ["---------", "---------", "---   ---",  "---   ---", "---   ---",  "---   ---"],
-	        "name": "Nearing",
-	        "desc": "Approach, the arrival of the new, growing; an honoured and powerful force comes nearer."
-	        }, {
-	        "id":   20,
-	        "lines": ["---   ---",  "---   ---",  "---   ---",  "---   ---", "---------", "---------"],
-	        "name": "Viewing",
-	        "desc": "Look at things from a distance, contemplate, let everything come into view, divine the meaning."
-	        }, {
-	        "id":   21,
-	        "lines": ["---------", "---   ---",  "---   ---",  "---------", "---   ---",  "---------"],
-	        "name": "Gnawing And\n    Biting Through",
-	        "desc": "Confront the problem, bite through the obstacle, be tenacious, reveal the essential."
-	        }, {
-	        "id":   22,
-	        "lines": ["---------", "---   ---",  "---------", "---   ---", "---   ---",  "---------"],
-	        "name": "Adorning",
-	        "desc": "Make outward appearance reflect inner worth; embellish, beautify, display courage and beauty to build inner value."
-	        }, {
-	        "id":   23,
-	        "lines": ["---   ---",  "---   ---",  "---   ---",  "---   ---", "---   ---",  "---------"],
-	        "name": "Stripping",
-	        "desc": "Strip away old ideas and habits, eliminate what is unusable, outmoded or worn out."
-	        }, {
-	        "id":   24,
-	        "lines": ["---------", "---   ---",  "---   ---",  "---   ---", "---   ---",  "---   ---"],
-	        "name": "Returning",
-	        "desc": "Energy and spirit return after a difficult time; renewal, re-birth, re-establish; new hope."
-	        }, {
-	        "id":   25,
-	        "lines": ["---------", "---   ---",  "---   ---",  "---------", "---------", "---------"],
-	        "name": "Without Embroiling",
-	        "desc": "Disentangle yourself; spontaneous, unplanned, direct; clean, pure, free from confusion or ulterior motives."
-	        }, {
-	        "id":   26,
-	        "lines": ["---------", "---------", "---------", "---   ---", "---   ---",  "---------"],
-	        "name": "Great Accumulating",
-	        "desc": "Concentrate, focus on a great idea; accumulate energy, bring everything together; a time for great effort and achievement."
-	        }, {
-	        "id":   27,
-	        "lines": ["---------", "---   ---",  "---   ---",  "---   ---", "---   ---",  "---------"],
-	        "name": "  Jaws",
-	        "desc": "Nourishing and being nourished, food and words; the mouth, your daily bread; take things in, swallow."
-	        }, {
-	        "id":   28,
-	        "lines": ["---   ---",  "---------", "---------", "---------", "---------", "---   ---"],
-	        "name": "Great Exceeding",
-	        "desc": "A crisis; gather all your force, don't be afraid to act alone; hold on to your ideals."
-	        }, {
-	        "id":   29,
-	        "lines": ["---   ---",  "---------", "---   ---",  "---   ---", "---------", "---   ---"],
-	        "name": "Repeating The Gorge",
-	        "desc": "Unavoidable danger; take the plunge, face your fear; practise, confront something repeatedly."
-	        }, {
-	        "id":   30,
-	        "lines": ["---------", "---   ---",  "---------", "---------", "---   ---",  "---------"],
-	        "name": "Radiance",
-	        "desc": "Light, warmth and spreading awareness; join with, adhere to; see clearly."
-	        }, {
-	        "id":   31,
-	        "lines": ["---   ---",  "---   ---",  "---------", "---------", "---------", "---   ---"],
-	        "name": "Conjoining",
-	        "desc": "Influence or stimulus to action, excite, mobilize; connection, bring together what belongs together."
-	        }, {
-	        "id":   32,
-	        "lines": ["---   ---",  "---------", "---------", "---------", "---   ---",  "---   ---"],
-	        "name": "Persevering",
-	        "desc": "Continue on, endure and renew the way, constant, consistent, continue in what is right."
-	        }, {
-	        "id":   33,
-	        "lines": ["---   ---",  "---   ---",  "---------", "---------", "---------", "---------"],
-	        "name": "Retiring",
-	        "desc": "Withdraw, conceal yourself, retreat; pull back in order to advance later."
-	        }, {
-	        "id":   34,
-	        "lines": ["---------", "---------", "---------", "---------", "---   ---",  "---   ---"],
-	        "name": "Great Invigorating",
-	        "desc": "Great strength, the strength of the Great, have a firm purpose, focus your strength and go forward."
-	        }, {
-	        "id":   35,
-	        "lines": ["---   ---",  "---   ---",  "---   ---",  "---------", "---   ---",  "---------"],
-	        "name": "Prospering",
-	        "desc": "Step into the light, advance surely, receive gifts, be promoted, spread prosperity, dawn of a new day."
-	        }, {
-	        "id":   36,
-	        "lines": ["---------", "---   ---",  "---------", "---   ---", "---   ---",  "---   ---"],
-	        "name": "Hiding Brightness",
-	        "desc": "Hide your light, protect yourself, accept the difficult task."
-	        }, {
-	        "id":   37,
-	        "lines": ["---------", "---   ---",  "---------", "---   ---", "---------", "---------"],
-	        "name": "Dwelling People",
-	        "desc": "Hold together, an enduring group; adapt, nourish, support; family, clan."
-	        }, {
-	        "id":   38,
-	        "lines": ["---------", "---------", "---   ---",  "---------", "---   ---",  "---------"],
-	        "name": "Diverging",
-	        "desc": "Opposition, discord; change conflict into creative tension through awareness."
-	        }, {
-	        "id":   39,
-	        "lines": ["---   ---",  "---   ---",  "---------", "---   ---", "---------", "---   ---"],
-	        "name": "Difficulties",
-	        "desc": "Confront obstacles; feel hampered or afflicted."
-	        }, {
-	        "id":   40,
-	        "lines": ["---   ---",  "---------", "---   ---",  "---------", "---   ---",  "---   ---"],
-	        "name": "Loosening",
-	        "desc": "Solve problems, untie knots, release blocked energy; liberation, end of suffering."
-	        }, {
-	        "id":   41,
-	        "lines": ["---------", "---------", "---   ---",  "---   ---", "---   ---",  "---------"],
-	        "name": "Diminishing",
-	        "desc": "Loss, decrease, sacrifice; concentrate, diminish involvements; aim at a higher goal."
-	        }, {
-	        "id":   42,
-	        "lines": ["---------", "---   ---",  "---   ---",  "---   ---", "---------", "---------"],
-	        "name": "Augmenting",
-	        "desc": "Increase, expand, develop, pour in more, a fertile and expansive time."
-	        }, {
-	        "id":   43,
-	        "lines": ["---------", "---------", "---------", "---------", "---------", "---   ---"],
-	        "name": "Deciding",
-	        "desc": "A critical moment, a breakthrough; decide and act clearly, clean it out and bring it to light."
-	        }, {
-	        "id":   44,
-	        "lines": ["---   ---",  "---------", "---------", "---------", "---------", "---------"],
-	        "name": "Coupling",
-	        "desc": "Opening, welcoming, an intense personal encounter; meet and act through the yin, sexual intercourse."
-	        }, {
-	        "id":   45,
-	        "lines": ["---   ---",  "---   ---",  "---   ---",  "---------", "---------", "---   ---"],
-	        "name": "Clustering",
-	        "desc": "Gather, assemble, collect, bunch together, crowds; a great effort brings great rewards."
-	        }, {
-	        "id":   46,
-	        "lines": ["---   ---",  "---------", "---------", "---   ---", "---   ---",  "---   ---"],
-	        "name": "Ascending",
-	        "desc": "Rise to a higher level, lift yourself, advance; climb up step by step."
-	        }, {
-	        "id":   47,
-	        "lines": ["---   ---",  "---------", "---   ---",  "---------", "---------", "---   ---"],
-	        "name": "Confining",
-	        "desc": "Oppression, restriction, being cut off; the moment of truth; turn inward, find a way to open communication."
-	        }, {
-	        "id":   48,
-	        "lines": ["---   ---",  "---------", "---------", "---   ---", "---------", "---   ---"],
-	        "name": "The Well",
-	        "desc": "Communicate, interact, in good order; the underlying structure, network; source of life-water necessary to all."
-	        }, {
-	        "id":   49,
-	        "lines": ["---------", "---   ---",  "---------", "---------", "---------", "---   ---"],
-	        "name": "Skinning",
-	        "desc": "Renew; moult, change radically, strip away the old, revolution, revolt."
-	        }, {
-	        "id":   50,
-	        "lines": ["---   ---",  "---------", "---------", "---------", "---   ---",  "---------"],
-	        "name": "The Vessel",
-	        "desc": "Transformation, reach to the spiritual level; found, consecrate, imagine, contain."
-	        }, {
-	        "id":   51,
-	        "lines": ["---------", "---   ---",  "---   ---",  "---------", "---   ---",  "---   ---"],
-	        "name": " Shake",
-	        "desc": "A disturbing and fertilizing shock; wake up, stir up, begin the new; return of life and love in spring."
-	        }, {
-	        "id":   52,
-	        "lines": ["---   ---",  "---   ---",  "---------", "---   ---", "---   ---",  "---------"],
-	        "name": " Bound",
-	        "desc": "Calm, still, stabilize; limit or boundary, end of a cycle; become an individual."
-	        }, {
-	        "id":   53,
-	        "lines": ["---   ---",  "---   ---",  "---------", "---   ---", "---------", "---------"],
-	        "name": "Gradual Advancing",
-	        "desc": "Step by step, smooth, adaptable, penetrate like water; the oldest daughter's marriage."
-	        }, {
-	        "id":   54,
-	        "lines": ["---------", "---------", "---   ---",  "---------", "---   ---",  "---   ---"],
-	        "name": "Converting The Maiden",
-	        "desc": "Choice or transformation over which you have no control; realize your hidden potential; passion, desire, irregular progress; the younger daughter's marriage."
-	        }, {
-	        "id":   55,
-	        "lines": ["---------", "---   ---",  "---------", "---------", "---   ---",  "---   ---"],
-	        "name": "Abounding",
-	        "desc": "Culmination, plenty, copious, profusion; generosity, opulence, full to overflowing."
-	        }, {
-	        "id":   56,
-	        "lines": ["---   ---",  "---   ---",  "---------", "---------", "---   ---",  "---------"],
-	        "name": "Sojourning",
-	        "desc": "Wandering, living in exile, searching for your individual truth; outside the social net, on a quest."
-	        }, {
-	        "id":   57,
-	        "lines": ["---   ---",  "---------", "---------", "---   ---", "---------", "---------"],
-	        "name": "Gently Penetrating",
-	        "desc": "Supple, flexible, subtle penetration; accept, let yourself be shaped by the situation; support or nourish from below."
-	        }, {
-	        "id":   58,
-	        "lines": ["---------", "---------", "---   ---",  "---------", "---------", "---   ---"],
-	        "name": "  Open",
-	        "desc": "Communication, self-expression; pleasure, joy, interaction; persuade, exchange, the marketplace."
-	        }, {
-	        "id":   59,
-	        "lines": ["---   ---",  "---------", "---   ---",  "---   ---", "---------", "---------"],
-	        "name": "Dispersing",
-	        "desc": "Dissolve, clear away, scatter, clear up; make fluid, eliminate obstacles and misundestandings."
-	        }, {
-	        "id":   60,
-	        "lines": ["---------", "---------", "---   ---",  "---   ---", "---------", "---   ---"],
-	        "name": "Articulating",
-	        "desc": "Give measure, limit and form; articulate thought and speech; rhythm, interval, chapter, units."
-	        }, {
-	        "id":   61,
-	        "lines": ["---------", "---------", "---   ---",  "---   ---", "---------", "---------"],
-	        "name": "Connecting To Centre",
-	        "desc": "Connection to the spirit; just, sincere, truthful; the power of a heart free of prejudice; connect the inner and outer parts of your life."
-	        }, {
-	        "id":   62,
-	        "lines": ["---   ---",  "---   ---",  "---------", "---------", "---   ---",  "---   ---"],
-	        "name": "Small Exceeding",
-	        "desc": "A time of transition, adapt to each different thing; be very careful, very small; excess yin."
-	        }, {
-	        "id":   63,
-	        "lines": ["---------", "---   ---",  "---------", "---   ---", "---------", "---   ---"],
-	        "name": "Already Fording",
-	        "desc": "Already underway, the action has begun; proceed actively, everything is in place and in order."
-	        }, {
-	        "id":   64,
-	        "lines": ["---   ---",  "---------", "---   ---",  "---------", "---   ---",  "---------"],
-	        "name": "Not Yet Fording",
-	        "desc": "On the edge of an important change; gather your energy, everything is possible; wait for the right moment."
-	        }
-	    ]
-	}`)
-
-	var coins, quiet bool = false, false
-	var showhex int
+	lang = detectLang(os.Args[1:])
+
+	var coins, interactive, nuclear, chinese, quiet bool = false, false, false, false, false
+	var method, tosses, numbers, source, when, dataFile, translation, compare, langFlag, output string
+	var svgFile, pngFile, colour, background, markColour string
+	var seed int64
+	var showhex, size int
 	var find string
-	flag.BoolVar(&coins, "c", false, "Use coins method instead of marbles")
-	flag.BoolVar(&quiet, "q", false, "Don't show descriptions")
-	flag.IntVar(&showhex, "s", 0, "Show specific hexagram (1-64) and its description")
-	flag.StringVar(&find, "f", "", "Find hexagram by its lines: x denotes Yang line, y denotes Yin line (starting from the bottom up)")
+	flag.BoolVar(&coins, "c", false, tr("Use coins method instead of marbles (same as -m coins)"))
+	flag.StringVar(&method, "m", "marbles", fmt.Sprintf(tr("Casting method: %s (list to show them)"), strings.Join(iching.Methods(), ", ")))
+	flag.StringVar(&method, "method", "marbles", tr("Same as -m"))
+	flag.StringVar(&tosses, "t", "", tr("Enter your own coin tosses: six groups of three h (heads) or t (tails), like \"hht tth hhh htt ttt hth\" (starting from the bottom up)"))
+	flag.StringVar(&numbers, "n", "", tr("Cast from two or three numbers, like \"3 8\" or \"3,8,5\": upper trigram, lower trigram, and their sum for the moving line"))
+	flag.BoolVar(&interactive, "i", false, tr("Enter your own coin tosses line by line"))
+	flag.Int64Var(&seed, "seed", 0, tr("Seed for a reproducible reading (default is taken from the clock)"))
+	flag.StringVar(&source, "rand", "math", tr("Randomness source: math, crypto or the path of an entropy file like /dev/urandom"))
+	flag.StringVar(&when, "time", "", tr("Moment to cast from with -m meihua, like \"2006-01-02 15:04\" (default is now)"))
+	flag.BoolVar(&nuclear, "nuclear", false, tr("Also show the nuclear hexagram"))
+	flag.StringVar(&dataFile, "data", "", tr("Load hexagrams from this JSON file (default is $XDG_CONFIG_HOME/cliching/hexagrams.json if present, else the built-in set)"))
+	flag.StringVar(&translation, "translation", "", fmt.Sprintf(tr("Built-in translation to use: %s (list to show them, default %s)"), strings.Join(iching.Translations(), ", "), iching.DefaultTranslation))
+	flag.StringVar(&compare, "compare", "", tr("With -s, show this translation side by side with the chosen one"))
+	flag.StringVar(&langFlag, "lang", "", tr("Language of the user interface and hexagram texts, like fi or de (default from LC_ALL, LC_MESSAGES or LANG)"))
+	flag.StringVar(&output, "o", "text", fmt.Sprintf(tr("Output format: %s"), strings.Join(outputFormats(), ", ")))
+	flag.StringVar(&svgFile, "svg", "", tr("Also draw the reading as an SVG image in this file"))
+	flag.StringVar(&pngFile, "png", "", tr("Also draw the reading as a PNG image in this file"))
+	flag.IntVar(&size, "size", 120, tr("Width of a line in drawn figures, in pixels"))
+	flag.StringVar(&colour, "colour", "#222222", tr("Colour of the lines in drawn figures"))
+	flag.StringVar(&background, "background", "#ffffff", tr("Background colour of drawn figures"))
+	flag.StringVar(&markColour, "mark", "#b22222", tr("Colour of the O and X marks on changing lines in drawn figures"))
+	flag.BoolVar(&chinese, "chinese", false, tr("Show Chinese names, pinyin and hexagram symbols"))
+	flag.BoolVar(&quiet, "q", false, tr("Don't show descriptions"))
+	flag.IntVar(&showhex, "s", 0, tr("Show specific hexagram (1-64), its description and related hexagrams"))
+	flag.StringVar(&find, "f", "", tr("Find hexagram by its lines: x denotes Yang line, y denotes Yin line, or use line values 6-9 like 789966 (starting from the bottom up)"))
+
+	flag.Usage = func() {
+		out := flag.CommandLine.Output()
+		fmt.Fprintf(out, tr("Usage of %s:")+"\n", os.Args[0])
+		fmt.Fprintf(out, "  %s [flags]\n", os.Args[0])
+		fmt.Fprintf(out, "  %s [flags] validate [file]\n", os.Args[0])
+		fmt.Fprintln(out, "\n"+tr("Flags:"))
+		flag.PrintDefaults()
+	}
 
 	flag.Parse()
 
-	phex := Hexagram{}
-	rhex := Hexagram{}
+	render, ok := renderers[output]
+	if !ok && output != "text" {
+		fmt.Fprintf(os.Stderr, tr("unknown output format %q")+"\n", output)
+		flag.Usage()
+		os.Exit(1)
+	}
 
-	var h Hexagrams
+	var style drawStyle
+	if svgFile != "" || pngFile != "" {
+		var err error
+		style, err = newDrawStyle(size, colour, background, markColour)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, trError(err))
+			os.Exit(1)
+		}
+	}
+
+	if translation == "list" {
+		for _, name := range iching.Translations() {
+			fmt.Println(name)
+		}
+		os.Exit(0)
+	}
 
-	err := json.Unmarshal(jsonData, &h)
+	if flag.Arg(0) == "validate" {
+		path := dataFile
+		if flag.NArg() > 1 {
+			path = flag.Arg(1)
+		}
+		h, err := loadHexagrams(path, translation)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, trError(err))
+			os.Exit(1)
+		}
+		fmt.Printf(tr("%d hexagrams, no problems found")+"\n", len(h.Hexagrams))
+		os.Exit(0)
+	}
+
+	h, err := loadHexagrams(dataFile, translation)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, trError(err))
+		os.Exit(1)
+	}
+
+	var cast = false
+	var lines [6]iching.Line
+	var primaryTitle = "  " + tr("Primary Figure")
+	var relatingTitle = "  " + tr("Relating Figure")
+	var nuclearTitle = "  " + tr("Nuclear Figure")
+
+	if method == "list" {
+		for _, name := range iching.Methods() {
+			fmt.Println(name)
+		}
+		os.Exit(0)
+	}
+	if coins {
+		method = "coins"
+	}
+	caster, err := iching.Lookup(method)
 	if err != nil {
-		fmt.Println(err)
+		fmt.Fprintln(os.Stderr, trError(err))
+		flag.Usage()
+		os.Exit(1)
 	}
 
-	var relating = false
-	var initialHxgrm, primaryShape, relatingShape [6]string
-	var primaryTitle = "  Primary Figure"
-	const relatingTitle string = "  Relating Figure"
+	if method == "meihua" {
+		if isFlagPassed("seed") {
+			fmt.Fprintln(os.Stderr, tr("-seed does not apply to -m meihua"))
+			os.Exit(1)
+		}
+		if isFlagPassed("time") {
+			t, err := parseTime(when)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, trError(err))
+				os.Exit(1)
+			}
+			caster, err = iching.NewPlumBlossom(t)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, trError(err))
+				os.Exit(1)
+			}
+		}
+	} else if isFlagPassed("time") {
+		fmt.Fprintln(os.Stderr, tr("-time only applies to -m meihua"))
+		os.Exit(1)
+	}
 
 	if isFlagPassed("s") {
-		if showhex < 1 || showhex > 64 {
+		hex, err := h.ByID(showhex)
+		if err != nil {
 			flag.Usage()
 			os.Exit(1)
-		} else {
-			phex.ID = h.Hexagrams[showhex-1].ID
-			phex.Name = h.Hexagrams[showhex-1].Name
-			phex.Lines = h.Hexagrams[showhex-1].Lines
-			phex.Desc = h.Hexagrams[showhex-1].Desc
-			printer(phex, "", quiet)
+		}
+		doc := newShowDoc(hex)
+		if svgFile != "" {
+			saveImage(svgFile, writeSVG, doc, style)
+		}
+		if pngFile != "" {
+			saveImage(pngFile, writePNG, doc, style)
+		}
+		if render != nil {
+			addRelations(h, &doc, hex)
+			if nuclear {
+				addNuclear(h, &doc, hex)
+			}
+			writeDoc(render, doc)
+			os.Exit(0)
+		}
+		if compare != "" {
+			other, err := iching.LocalTranslation(compare, lang)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, trError(err))
+				os.Exit(1)
+			}
+			ohex, err := other.ByID(showhex)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, trError(err))
+				os.Exit(1)
+			}
+			name := translation
+			if name == "" {
+				name = iching.DefaultTranslation
+			}
+			if dataFile != "" {
+				name = filepath.Base(dataFile)
+			}
+			printer(hex, "", true, chinese)
+			printSideBySide(textColumns(name, hex, compare, ohex))
 			os.Exit(0)
 		}
+		printer(hex, "", quiet, chinese)
+		printRelations(h, hex)
+		if nuclear {
+			printNuclear(h, hex, nuclearTitle, quiet, chinese)
+		}
+		os.Exit(0)
 	}
 
-	rand.Seed(time.Now().UnixNano())
-
+	var how = method
 	if isFlagPassed("f") {
-		initialHxgrm = findHxgrmManually(find)
-		primaryTitle = ""
+		how = "find"
+		lines, err = iching.ParseLines(find)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, trError(err))
+			flag.Usage()
+			os.Exit(1)
+		}
+	} else if isFlagPassed("t") {
+		how = "tosses"
+		lines, err = iching.ParseTosses(tosses)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, trError(err))
+			flag.Usage()
+			os.Exit(1)
+		}
+	} else if isFlagPassed("n") {
+		how = "numbers"
+		lines, err = iching.ParseNumbers(numbers)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, trError(err))
+			flag.Usage()
+			os.Exit(1)
+		}
+	} else if interactive {
+		how = "tosses"
+		prompt := os.Stdout
+		if render != nil {
+			prompt = os.Stderr
+		}
+		lines, err = readTosses(os.Stdin, prompt)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, trError(err))
+			os.Exit(1)
+		}
 	} else {
-		initialHxgrm = generateHexagram(coins)
-	}
-
-	for i := 0; i < len(initialHxgrm); i++ {
-		if initialHxgrm[i] == "--- X ---" {
-			primaryShape[i] = "---   ---"
-			relatingShape[i] = "---------"
-			relating = true
-		} else if initialHxgrm[i] == "----O----" {
-			primaryShape[i] = "---------"
-			relatingShape[i] = "---   ---"
-			relating = true
-		} else if initialHxgrm[i] == "---------" {
-			primaryShape[i] = "---------"
-			relatingShape[i] = "---------"
-		} else if initialHxgrm[i] == "---   ---" {
-			primaryShape[i] = "---   ---"
-			relatingShape[i] = "---   ---"
-		}
-	}
-
-	for match := true; match; match = false {
-		for i := 0; i < len(h.Hexagrams); i++ {
-			match := findHexagram(primaryShape, h.Hexagrams[i].Lines)
-			if match {
-				phex.ID = h.Hexagrams[i].ID
-				phex.Name = h.Hexagrams[i].Name
-				if relating {
-					phex.Lines = initialHxgrm
-				} else {
-					phex.Lines = h.Hexagrams[i].Lines
-				}
-				phex.Desc = h.Hexagrams[i].Desc
-				break
+		var src *iching.ReaderSource
+		switch source {
+		case "math":
+			if !isFlagPassed("seed") {
+				seed = time.Now().UnixNano()
+			}
+			lines = caster.Cast(rand.New(rand.NewSource(seed)))
+			cast = method != "meihua"
+		case "crypto":
+			src = iching.NewCryptoSource()
+		default:
+			f, err := os.Open(source)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, trError(err))
+				os.Exit(1)
+			}
+			defer f.Close()
+			src = iching.NewReaderSource(f)
+		}
+		if src != nil {
+			if isFlagPassed("seed") {
+				fmt.Fprintln(os.Stderr, tr("-seed only applies to the math randomness source"))
+				os.Exit(1)
+			}
+			lines = caster.Cast(rand.New(src))
+			if err := src.Err(); err != nil {
+				fmt.Fprintf(os.Stderr, tr("reading %s: %v")+"\n", source, err)
+				os.Exit(1)
 			}
 		}
 	}
 
-	if relating {
-		for match := true; match; match = false {
-			for i := 0; i < len(h.Hexagrams); i++ {
-				match := findHexagram(relatingShape, h.Hexagrams[i].Lines)
-				if match {
-					rhex.ID = h.Hexagrams[i].ID
-					rhex.Name = h.Hexagrams[i].Name
-					rhex.Lines = h.Hexagrams[i].Lines
-					rhex.Desc = h.Hexagrams[i].Desc
-					break
-				}
-			}
+	reading, err := h.Resolve(lines)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, trError(err))
+		os.Exit(1)
+	}
+
+	doc := newReadingDoc(how, reading)
+	if p, ok := caster.(iching.PlumBlossom); ok && how == "meihua" && !p.Time.IsZero() {
+		doc.Timestamp = p.Time
+	}
+	if cast {
+		doc.Seed = &seed
+	}
+	if svgFile != "" {
+		saveImage(svgFile, writeSVG, doc, style)
+	}
+	if pngFile != "" {
+		saveImage(pngFile, writePNG, doc, style)
+	}
+	if render != nil {
+		if nuclear {
+			addNuclear(h, &doc, reading.Primary)
 		}
+		writeDoc(render, doc)
+		return
 	}
 
-	printer(phex, primaryTitle, quiet)
-	if relating {
-		printer(rhex, relatingTitle, quiet)
+	if isFlagPassed("f") && !reading.Changing {
+		primaryTitle = ""
+	}
+
+	phex := reading.Primary
+	phex.Lines = reading.Lines
+	printer(phex, primaryTitle, quiet, chinese)
+	printChanging(reading, quiet)
+	if reading.Changing {
+		printer(reading.Relating, relatingTitle, quiet, chinese)
+	}
+	if nuclear {
+		printNuclear(h, reading.Primary, nuclearTitle, quiet, chinese)
+	}
+	if cast {
+		fmt.Printf("  %s: %d\n", tr("Seed"), seed)
 	}
 }
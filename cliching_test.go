@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tyybbi/cliching/iching"
+)
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{in: "2024-02-10 12:30", want: time.Date(2024, 2, 10, 12, 30, 0, 0, time.Local)},
+		{in: "2024-02-10T12:30", want: time.Date(2024, 2, 10, 12, 30, 0, 0, time.Local)},
+		{in: "2024-02-10", want: time.Date(2024, 2, 10, 0, 0, 0, 0, time.Local)},
+		{in: "2024-02-10T12:30:00+08:00", want: time.Date(2024, 2, 10, 12, 30, 0, 0, time.FixedZone("", 8*60*60))},
+		{in: "10.2.2024", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseTime(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseTime(%q) error = %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !got.Equal(tt.want) {
+			t.Errorf("parseTime(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTextColumns(t *testing.T) {
+	keywords := iching.Hexagram{Name: "Force", Desc: "Strength.", Judgement: "Force: supreme success.", Image: "Heaven moves."}
+	legge := iching.Hexagram{Name: "Khien", Judgement: "What is great and originating, penetrating, advantageous, correct and firm."}
+
+	left, right := textColumns("keywords", keywords, "legge", legge)
+	if len(left) != len(right) {
+		t.Fatalf("columns have %d and %d lines, want the same", len(left), len(right))
+	}
+	for i := range left {
+		l, r := strings.TrimSpace(left[i]), strings.TrimSpace(right[i])
+		if l == "Judgement" && r != "Judgement" || r == "Judgement" && l != "Judgement" {
+			t.Errorf("line %d: %q beside %q, want the judgements side by side", i, left[i], right[i])
+		}
+		if r == "Strength." {
+			t.Errorf("line %d: description in the legge column", i)
+		}
+	}
+}
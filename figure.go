@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"os"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/tyybbi/cliching/iching"
+)
+
+// drawStyle holds the size and colours figures are drawn with by the
+// image renderers. Size is the width of a line in pixels.
+type drawStyle struct {
+	Size       int
+	Colour     color.RGBA
+	Background color.RGBA
+	Mark       color.RGBA
+}
+
+// maxSize bounds -size so a PNG stays within a few tens of megabytes
+const maxSize = 2000
+
+// newDrawStyle checks the size and parses colours given as #rrggbb
+func newDrawStyle(size int, colour, background, mark string) (drawStyle, error) {
+	if size < 24 {
+		return drawStyle{}, fmt.Errorf(tr("size %d is too small, want at least 24"), size)
+	}
+	if size > maxSize {
+		return drawStyle{}, fmt.Errorf(tr("size %d is too large, want at most %d"), size, maxSize)
+	}
+	style := drawStyle{Size: size}
+	for _, c := range []struct {
+		s   string
+		dst *color.RGBA
+	}{{colour, &style.Colour}, {background, &style.Background}, {mark, &style.Mark}} {
+		if len(c.s) != 7 || c.s[0] != '#' {
+			return drawStyle{}, fmt.Errorf(tr("invalid colour %q: want a form like #b22222"), c.s)
+		}
+		rgb, err := strconv.ParseUint(c.s[1:], 16, 32)
+		if err != nil {
+			return drawStyle{}, fmt.Errorf(tr("invalid colour %q: want a form like #b22222"), c.s)
+		}
+		*c.dst = color.RGBA{uint8(rgb >> 16), uint8(rgb >> 8), uint8(rgb), 0xff}
+	}
+	return style, nil
+}
+
+// changeMark is the O or X drawn beside a changing line, centred on
+// (X, Y) with radius R
+type changeMark struct {
+	X, Y, R int
+	Yang    bool
+}
+
+// caption is a label centred at X with its baseline at Y
+type caption struct {
+	X, Y, Size int
+	Text       string
+}
+
+// figureLayout places the parts of one or more figures, side by side
+// with arrows between them, in an image of Width by Height pixels
+type figureLayout struct {
+	Width, Height int
+	Bars          []image.Rectangle
+	Marks         []changeMark
+	Captions      []caption
+	Arrows        [][]image.Point
+}
+
+// layoutReading lays out the figures of a reading: the primary figure
+// as cast and, if lines change, an arrow to the relating figure
+func layoutReading(doc readingDoc, style drawStyle) figureLayout {
+	figures := []hexagramDoc{doc.Primary}
+	figures[0].Lines = doc.Lines
+	if doc.Relating != nil {
+		figures = append(figures, *doc.Relating)
+	}
+
+	u := style.Size
+	barH := u / 6
+	space := u / 10
+	gap := u / 5
+	markW := u / 4
+	margin := u / 4
+	arrowW := u / 2
+	captionSize := u / 8
+	if captionSize < 10 {
+		captionSize = 10
+	}
+	figW := u + markW
+	figH := 6*barH + 5*space
+
+	l := figureLayout{Height: 2*margin + figH + 2*captionSize}
+	x := margin
+	for i, hex := range figures {
+		text := fmt.Sprintf("%d. %s", hex.ID, hex.Name)
+		// leave room for captions with letters up to 4/5 of their size wide
+		colW := figW
+		if w := utf8.RuneCountInString(text) * captionSize * 4 / 5; w > colW {
+			colW = w
+		}
+		if i > 0 {
+			l.Arrows = append(l.Arrows, arrow(x-arrowW+arrowW/5, x-arrowW/5, margin+figH/2, barH))
+		}
+		fx := x + (colW-figW)/2
+		for j, v := range hex.Lines {
+			line := iching.Line(v)
+			y := margin + (5-j)*(barH+space)
+			if line.IsYang() {
+				l.Bars = append(l.Bars, image.Rect(fx, y, fx+u, y+barH))
+			} else {
+				half := (u - gap) / 2
+				l.Bars = append(l.Bars, image.Rect(fx, y, fx+half, y+barH), image.Rect(fx+u-half, y, fx+u, y+barH))
+			}
+			if line.IsChanging() {
+				l.Marks = append(l.Marks, changeMark{X: fx + u + markW/2, Y: y + barH/2, R: barH * 2 / 5, Yang: line.IsYang()})
+			}
+		}
+		l.Captions = append(l.Captions, caption{
+			X:    fx + u/2,
+			Y:    margin + figH + captionSize*3/2,
+			Size: captionSize,
+			Text: text,
+		})
+		x += colW + arrowW
+	}
+	l.Width = x - arrowW + margin
+	return l
+}
+
+// arrow returns the outline of an arrow pointing right from x0 to x1
+// along y, with a shaft as thick as a third of h and a head of h
+func arrow(x0, x1, y, h int) []image.Point {
+	shaft, head := h/6+1, h/2+1
+	neck := x1 - head
+	return []image.Point{
+		{x0, y - shaft}, {neck, y - shaft}, {neck, y - head},
+		{x1, y},
+		{neck, y + head}, {neck, y + shaft}, {x0, y + shaft},
+	}
+}
+
+// saveImage writes a reading to the file at path with the given
+// image renderer
+func saveImage(path string, write func(io.Writer, readingDoc, drawStyle) error, doc readingDoc, style drawStyle) {
+	f, err := os.Create(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, trError(err))
+		os.Exit(1)
+	}
+	if err := write(f, doc, style); err != nil {
+		f.Close()
+		fmt.Fprintln(os.Stderr, trError(err))
+		os.Exit(1)
+	}
+	if err := f.Close(); err != nil {
+		fmt.Fprintln(os.Stderr, trError(err))
+		os.Exit(1)
+	}
+}
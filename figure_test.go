@@ -0,0 +1,62 @@
+package main
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestNewDrawStyle(t *testing.T) {
+	tests := []struct {
+		size                     int
+		colour, background, mark string
+		want                     drawStyle
+		wantErr                  bool
+	}{
+		{
+			size: 120, colour: "#000000", background: "#ffffff", mark: "#b22222",
+			want: drawStyle{
+				Size:       120,
+				Colour:     color.RGBA{0, 0, 0, 0xff},
+				Background: color.RGBA{0xff, 0xff, 0xff, 0xff},
+				Mark:       color.RGBA{0xb2, 0x22, 0x22, 0xff},
+			},
+		},
+		{
+			size: 24, colour: "#0A0b0C", background: "#ffffff", mark: "#000000",
+			want: drawStyle{
+				Size:       24,
+				Colour:     color.RGBA{0x0a, 0x0b, 0x0c, 0xff},
+				Background: color.RGBA{0xff, 0xff, 0xff, 0xff},
+				Mark:       color.RGBA{0, 0, 0, 0xff},
+			},
+		},
+		{
+			size: maxSize, colour: "#000000", background: "#ffffff", mark: "#b22222",
+			want: drawStyle{
+				Size:       maxSize,
+				Colour:     color.RGBA{0, 0, 0, 0xff},
+				Background: color.RGBA{0xff, 0xff, 0xff, 0xff},
+				Mark:       color.RGBA{0xb2, 0x22, 0x22, 0xff},
+			},
+		},
+		{size: maxSize + 1, colour: "#000000", background: "#ffffff", mark: "#b22222", wantErr: true},
+		{size: 1 << 30, colour: "#000000", background: "#ffffff", mark: "#b22222", wantErr: true},
+		{size: 23, colour: "#000000", background: "#ffffff", mark: "#b22222", wantErr: true},
+		{size: -1, colour: "#000000", background: "#ffffff", mark: "#b22222", wantErr: true},
+		{size: 120, colour: "000000", background: "#ffffff", mark: "#b22222", wantErr: true},
+		{size: 120, colour: "#000000", background: "#fff", mark: "#b22222", wantErr: true},
+		{size: 120, colour: "#000000", background: "#ffffff", mark: "#b22222ff", wantErr: true},
+		{size: 120, colour: "#000000", background: "#ffffff", mark: "red", wantErr: true},
+		{size: 120, colour: "#00000g", background: "#ffffff", mark: "#b22222", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := newDrawStyle(tt.size, tt.colour, tt.background, tt.mark)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("newDrawStyle(%d, %q, %q, %q) error = %v, want error %v", tt.size, tt.colour, tt.background, tt.mark, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("newDrawStyle(%d, %q, %q, %q) = %+v, want %+v", tt.size, tt.colour, tt.background, tt.mark, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/tyybbi/cliching
+
+go 1.16
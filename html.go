@@ -0,0 +1,136 @@
+package main
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/tyybbi/cliching/iching"
+)
+
+// barView is one line of a figure as drawn in the HTML report; Mark
+// is O or X for a changing line
+type barView struct {
+	Yang bool
+	Mark string
+}
+
+// lineView is a line statement in the HTML report
+type lineView struct {
+	Pos      int
+	Text     string
+	Changing bool
+}
+
+// figureView is a hexagram section in the HTML report
+type figureView struct {
+	Title string
+	Hex   hexagramDoc
+	Bars  []barView
+	Lines []lineView
+}
+
+var htmlTemplate = template.Must(template.New("reading").Funcs(template.FuncMap{"tr": tr}).Parse(`<!DOCTYPE html>
+<html{{if .Lang}} lang="{{.Lang}}"{{end}}>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Georgia, serif; max-width: 46em; margin: 2em auto; padding: 0 1em; color: #222; background: #fdfcf8; line-height: 1.5; }
+.meta { color: #777; font-style: italic; }
+.figures { display: flex; flex-wrap: wrap; gap: 3em; align-items: flex-start; }
+.figure { flex: 1 1 18em; }
+.bars { width: 9em; margin: 1em 0; }
+.bar { position: relative; display: flex; justify-content: space-between; height: 1em; margin: 0.45em 0; }
+.bar span { width: 42%; background: #222; }
+.bar.yang span { width: 100%; }
+.bar.changing span { background: #b22222; }
+.bar b { position: absolute; left: 0; right: 0; text-align: center; line-height: 1em; color: #b22222; }
+.bar.yang b { color: #fdfcf8; }
+.chinese { font-size: 1.2em; }
+h3 { margin-bottom: 0.2em; font-size: 1em; }
+ol { padding-left: 1.5em; }
+li.changing { color: #b22222; font-weight: bold; }
+.relations { list-style: none; padding: 0; color: #555; }
+</style>
+</head>
+<body>
+{{if .Meta}}<p class="meta">{{.Meta}}</p>
+{{end}}<div class="figures">
+{{range .Figures}}<section class="figure">
+<h2>{{if .Title}}{{.Title}}: {{end}}{{.Hex.ID}}. {{.Hex.Name}}</h2>
+{{if .Hex.Chinese}}<p class="chinese">{{.Hex.Symbol}} {{.Hex.Chinese}} {{.Hex.Pinyin}}</p>
+{{end}}<div class="bars">
+{{range .Bars}}<div class="bar{{if .Yang}} yang{{end}}{{if .Mark}} changing{{end}}"><span></span>{{if not .Yang}}<span></span>{{end}}{{if .Mark}}<b>{{.Mark}}</b>{{end}}</div>
+{{end}}</div>
+{{if .Hex.Desc}}<p>{{.Hex.Desc}}</p>
+{{end}}{{if .Hex.Judgement}}<h3>{{tr "Judgement"}}</h3>
+<p>{{.Hex.Judgement}}</p>
+{{end}}{{if .Hex.Image}}<h3>{{tr "Image"}}</h3>
+<p>{{.Hex.Image}}</p>
+{{end}}{{if .Lines}}<h3>{{tr "Lines"}}</h3>
+<ol>
+{{range .Lines}}<li value="{{.Pos}}"{{if .Changing}} class="changing"{{end}}>{{.Text}}</li>
+{{end}}</ol>
+{{end}}</section>
+{{end}}</div>
+{{if .Inverse}}<ul class="relations">
+<li>{{tr "Inverse"}}: {{.Inverse.ID}}. {{.Inverse.Name}}</li>
+<li>{{tr "Complementary"}}: {{.Complement.ID}}. {{.Complement.Name}}</li>
+</ul>
+{{end}}</body>
+</html>
+`))
+
+// writeHTML writes a reading as a self-contained HTML page, with the
+// figures drawn as bars and changing lines marked
+func writeHTML(w io.Writer, doc readingDoc) error {
+	data := struct {
+		Lang    string
+		Title   string
+		Meta    string
+		Figures []figureView
+
+		Inverse, Complement *hexagramDoc
+	}{Lang: lang, Inverse: doc.Inverse, Complement: doc.Complement}
+
+	primaryTitle := tr("Primary Figure")
+	if doc.Method == "show" {
+		primaryTitle = ""
+	} else {
+		data.Meta = describeReading(doc)
+	}
+	data.Title = doc.Primary.Name
+	if doc.Relating != nil {
+		data.Title += " → " + doc.Relating.Name
+	}
+
+	data.Figures = append(data.Figures, newFigureView(doc.Primary, primaryTitle, doc.Lines))
+	if doc.Relating != nil {
+		data.Figures = append(data.Figures, newFigureView(*doc.Relating, tr("Relating Figure"), doc.Relating.Lines))
+	}
+	if doc.Nuclear != nil {
+		data.Figures = append(data.Figures, newFigureView(*doc.Nuclear, tr("Nuclear Figure"), doc.Nuclear.Lines))
+	}
+	return htmlTemplate.Execute(w, data)
+}
+
+// newFigureView lays out a hexagram drawn with the given line values
+func newFigureView(hex hexagramDoc, title string, lines []int) figureView {
+	fig := figureView{Title: title, Hex: hex}
+	for i := len(lines) - 1; i >= 0; i-- {
+		l := iching.Line(lines[i])
+		bar := barView{Yang: l.IsYang()}
+		if l == iching.OldYang {
+			bar.Mark = "O"
+		} else if l == iching.OldYin {
+			bar.Mark = "X"
+		}
+		fig.Bars = append(fig.Bars, bar)
+	}
+	for i, text := range hex.Texts {
+		if text != "" {
+			fig.Lines = append(fig.Lines, lineView{Pos: i + 1, Text: text, Changing: iching.Line(lines[i]).IsChanging()})
+		}
+	}
+	return fig
+}
@@ -0,0 +1,122 @@
+package iching
+
+import (
+	"math/rand"
+	"regexp"
+)
+
+// castCoins generates a fresh hexagram, bottom up, by tossing three
+// coins for each line
+func castCoins(rnd *rand.Rand) [6]Line {
+	var freshHexagram [6]Line
+
+	// heads count 3, tails 2, so the sum is the line value
+	for i := 0; i < len(freshHexagram); i++ {
+		c1 := rnd.Intn(4-2) + 2
+		c2 := rnd.Intn(4-2) + 2
+		c3 := rnd.Intn(4-2) + 2
+		freshHexagram[i] = Line(c1 + c2 + c3)
+	}
+	return freshHexagram
+}
+
+// castMarbles generates a fresh hexagram, bottom up, by drawing from a
+// bag of 16 marbles weighted like the yarrow stalk odds
+func castMarbles(rnd *rand.Rand) [6]Line {
+	var freshHexagram [6]Line
+
+	marbles := [16]Line{
+		OldYin,
+		OldYang, OldYang, OldYang,
+		YoungYang, YoungYang, YoungYang, YoungYang, YoungYang,
+		YoungYin, YoungYin, YoungYin, YoungYin, YoungYin,
+		YoungYin, YoungYin}
+
+	for i := 0; i < len(freshHexagram); i++ {
+		line := rnd.Intn(len(marbles))
+		freshHexagram[i] = marbles[line]
+	}
+	return freshHexagram
+}
+
+// castYarrow generates a fresh hexagram, bottom up, by dividing 49
+// yarrow stalks three times for each line
+func castYarrow(rnd *rand.Rand) [6]Line {
+	var freshHexagram [6]Line
+
+	for i := 0; i < len(freshHexagram); i++ {
+		stalks := 49
+		for op := 0; op < 3; op++ {
+			stalks -= divideStalks(rnd, stalks)
+		}
+		freshHexagram[i] = Line(stalks / 4)
+	}
+	return freshHexagram
+}
+
+// maxPartings bounds how often divideStalks parts the stalks again
+const maxPartings = 100
+
+// divideStalks performs one counting operation and returns the number
+// of stalks set aside. The stalks are parted at random into two heaps,
+// one stalk is taken from the right heap, and both heaps are counted
+// off by fours; the taken stalk and both remainders are set aside.
+// A fair source all but never needs a second parting; one that has run
+// dry keeps giving the same bits, so after maxPartings the split is
+// forced into range instead of looping for ever.
+func divideStalks(rnd *rand.Rand, stalks int) int {
+	left := 0
+	for try := 0; try < maxPartings && (left == 0 || stalks-left < 2); try++ {
+		left = 0
+		for i := 0; i < stalks; i++ {
+			left += rnd.Intn(2)
+		}
+	}
+	if left == 0 {
+		left = 1
+	} else if stalks-left < 2 {
+		left = stalks - 2
+	}
+	right := stalks - left - 1
+
+	return 1 + remainderOfFour(left) + remainderOfFour(right)
+}
+
+// remainderOfFour counts n off by fours; a remainder of zero counts as four
+func remainderOfFour(n int) int {
+	r := n % 4
+	if r == 0 {
+		return 4
+	}
+	return r
+}
+
+var (
+	manualRe  = regexp.MustCompile(`^[xy]{6}$`)
+	numericRe = regexp.MustCompile(`^[6-9]{6}$`)
+)
+
+// ParseLines builds hexagram lines from a string like "xyyxxy", where
+// x denotes a Yang line and y a Yin line, or from traditional line
+// values like "789966", starting from the bottom up
+func ParseLines(find string) ([6]Line, error) {
+	var shape [6]Line
+
+	switch {
+	case manualRe.MatchString(find):
+		for i := range find {
+			if find[i] == 'x' {
+				shape[i] = YoungYang
+			} else {
+				shape[i] = YoungYin
+			}
+		}
+	case numericRe.MatchString(find):
+		for i := range find {
+			shape[i] = Line(find[i] - '0')
+		}
+	default:
+		return shape, errorf("invalid lines %q: want six x or y characters or six digits 6-9", find)
+	}
+	return shape, nil
+}
@@ -0,0 +1,76 @@
+package iching
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// lineOdds casts n hexagrams and returns how often each line value came
+// up, in sixteenths
+func lineOdds(cast func(*rand.Rand) [6]Line, n int) map[Line]float64 {
+	rnd := rand.New(rand.NewSource(1))
+	counts := make(map[Line]int)
+	for i := 0; i < n; i++ {
+		for _, l := range cast(rnd) {
+			counts[l]++
+		}
+	}
+	odds := make(map[Line]float64)
+	for l, c := range counts {
+		odds[l] = float64(c) * 16 / float64(6*n)
+	}
+	return odds
+}
+
+func TestCastOdds(t *testing.T) {
+	tests := []struct {
+		name string
+		cast func(*rand.Rand) [6]Line
+		want map[Line]float64
+	}{
+		{"yarrow", castYarrow, map[Line]float64{OldYin: 1, YoungYang: 5, YoungYin: 7, OldYang: 3}},
+		{"marbles", castMarbles, map[Line]float64{OldYin: 1, YoungYang: 5, YoungYin: 7, OldYang: 3}},
+		{"coins", castCoins, map[Line]float64{OldYin: 2, YoungYang: 6, YoungYin: 6, OldYang: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lineOdds(tt.cast, 50000)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got line values %v, want %v", got, tt.want)
+			}
+			for l, want := range tt.want {
+				if d := got[l] - want; d < -0.1 || d > 0.1 {
+					t.Errorf("line %d came up %.3f/16 of the time, want %v/16", l, got[l], want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseLines(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    [6]Line
+		wantErr bool
+	}{
+		{in: "xyyxxy", want: [6]Line{7, 8, 8, 7, 7, 8}},
+		{in: "789966", want: [6]Line{7, 8, 9, 9, 6, 6}},
+		{in: "xxxxxx", want: [6]Line{7, 7, 7, 7, 7, 7}},
+		{in: "", wantErr: true},
+		{in: "xyyxx", wantErr: true},
+		{in: "xyyxxyx", wantErr: true},
+		{in: "XYYXXY", wantErr: true},
+		{in: "789965", wantErr: true},
+		{in: "78996x", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLines(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLines(%q) error = %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseLines(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package iching
+
+import (
+	"embed"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// dataFS holds the built-in hexagram sets, one JSON file per translation
+// and locale, named like keywords.json or keywords.fi.json
+//
+//go:embed data/*.json
+var dataFS embed.FS
+
+// DefaultTranslation names the built-in set used unless another is chosen
+const DefaultTranslation = "keywords"
+
+// Default returns the built-in hexagram set of the default translation
+func Default() (Hexagrams, error) {
+	return Translation(DefaultTranslation)
+}
+
+// Translation returns the built-in hexagram set of the named translation
+func Translation(name string) (Hexagrams, error) {
+	data, err := dataFS.ReadFile(path.Join("data", name+".json"))
+	if err != nil {
+		return Hexagrams{}, errorf("unknown translation %q", name)
+	}
+	h, err := Load(data)
+	if err != nil {
+		return Hexagrams{}, errorf("translation %s: %v", name, err)
+	}
+	return h, nil
+}
+
+// LocalTranslation returns the named translation in the language lang
+// (like "fi" or "de") when one is built in, else the translation itself.
+// Texts missing from the language version are taken from the translation.
+func LocalTranslation(name, lang string) (Hexagrams, error) {
+	base, err := Translation(name)
+	if err != nil || lang == "" {
+		return base, err
+	}
+	if _, err := fs.Stat(dataFS, path.Join("data", name+"."+lang+".json")); err != nil {
+		return base, nil
+	}
+	local, err := Translation(name + "." + lang)
+	if err != nil {
+		return Hexagrams{}, err
+	}
+	for i := range local.Hexagrams {
+		hex := &local.Hexagrams[i]
+		b, err := base.ByID(hex.ID)
+		if err != nil {
+			continue
+		}
+		fill := func(s *string, from string) {
+			if *s == "" {
+				*s = from
+			}
+		}
+		fill(&hex.Chinese, b.Chinese)
+		fill(&hex.Pinyin, b.Pinyin)
+		fill(&hex.Symbol, b.Symbol)
+		fill(&hex.Desc, b.Desc)
+		fill(&hex.Judgement, b.Judgement)
+		fill(&hex.Image, b.Image)
+		for j := range hex.Texts {
+			fill(&hex.Texts[j], b.Texts[j])
+		}
+	}
+	return local, nil
+}
+
+// Translations returns the names of the built-in translations, sorted.
+// Locale variants are not listed; see LocalTranslation.
+func Translations() []string {
+	files, _ := fs.Glob(dataFS, "data/*.json")
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		name := strings.TrimSuffix(path.Base(f), ".json")
+		if !strings.Contains(name, ".") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,44 @@
+package iching
+
+import "testing"
+
+func TestTranslations(t *testing.T) {
+	for _, name := range Translations() {
+		h, err := Translation(name)
+		if err != nil {
+			t.Fatalf("translation %s: %v", name, err)
+		}
+		for _, hex := range h.Hexagrams {
+			if hex.Judgement == "" {
+				t.Errorf("translation %s: hexagram %d has no judgement", name, hex.ID)
+			}
+		}
+	}
+}
+
+func TestLocalTranslation(t *testing.T) {
+	base, err := Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, lang := range []string{"fi", "de"} {
+		h, err := LocalTranslation(DefaultTranslation, lang)
+		if err != nil {
+			t.Fatalf("LocalTranslation(%s): %v", lang, err)
+		}
+		for _, hex := range h.Hexagrams {
+			b, _ := base.ByID(hex.ID)
+			if hex.Name == b.Name && hex.Desc == b.Desc {
+				t.Errorf("%s: hexagram %d is not translated", lang, hex.ID)
+			}
+			if hex.Judgement == "" || hex.Image == "" {
+				t.Errorf("%s: hexagram %d lacks its judgement or image", lang, hex.ID)
+			}
+			for i, text := range hex.Texts {
+				if text == "" {
+					t.Errorf("%s: hexagram %d lacks the text of line %d", lang, hex.ID, i+1)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package iching
+
+import "fmt"
+
+// Error is an error reported by this package. Its message is Format
+// filled in with Args; they are kept apart so that a program can show
+// the message in another language by translating Format.
+type Error struct {
+	Format string
+	Args   []interface{}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf(e.Format, e.Args...)
+}
+
+func errorf(format string, a ...interface{}) error {
+	return &Error{Format: format, Args: a}
+}
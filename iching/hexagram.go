@@ -0,0 +1,83 @@
+// Package iching casts I Ching hexagrams and looks them up from a
+// hexagram data set.
+package iching
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Hexagram holds data parsed from JSON file. Lines and the line
+// statements in Texts run bottom up.
+type Hexagram struct {
+	ID        int       `json:"id"`
+	Lines     [6]Line   `json:"lines"`
+	Name      string    `json:"name"`
+	Chinese   string    `json:"chinese"`
+	Pinyin    string    `json:"pinyin"`
+	Symbol    string    `json:"symbol"`
+	Desc      string    `json:"desc"`
+	Judgement string    `json:"judgement"`
+	Image     string    `json:"image"`
+	Texts     [6]string `json:"texts"`
+}
+
+// Hexagrams holds hexagrams parsed from JSON file
+type Hexagrams struct {
+	Hexagrams []Hexagram `json:"hexagrams"`
+}
+
+// Load parses a hexagram set from JSON data and validates it
+func Load(data []byte) (Hexagrams, error) {
+	var h Hexagrams
+	if err := json.Unmarshal(data, &h); err != nil {
+		return Hexagrams{}, errorf("parsing hexagram data: %v", err)
+	}
+	if err := h.Validate(); err != nil {
+		return Hexagrams{}, err
+	}
+	return h, nil
+}
+
+// LoadFile reads and parses a hexagram set from a JSON file
+func LoadFile(path string) (Hexagrams, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Hexagrams{}, err
+	}
+	h, err := Load(data)
+	if err != nil {
+		return Hexagrams{}, errorf("%s: %v", path, err)
+	}
+	return h, nil
+}
+
+// ByID returns the hexagram with the given number (1-64)
+func (h Hexagrams) ByID(id int) (Hexagram, error) {
+	for _, hex := range h.Hexagrams {
+		if hex.ID == id {
+			return hex, nil
+		}
+	}
+	return Hexagram{}, errorf("no hexagram with id %d", id)
+}
+
+// ByLines returns the hexagram drawn with the given lines, bottom up.
+// Moving lines are matched as they stand, before they change.
+func (h Hexagrams) ByLines(lines [6]Line) (Hexagram, error) {
+	for _, hex := range h.Hexagrams {
+		if findHexagram(lines, hex.Lines) {
+			return hex, nil
+		}
+	}
+	return Hexagram{}, errorf("no hexagram with lines %d", lines)
+}
+
+func findHexagram(a [6]Line, b [6]Line) bool {
+	for i, v := range a {
+		if v.IsYang() != b[i].IsYang() {
+			return false
+		}
+	}
+	return true
+}
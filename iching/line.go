@@ -0,0 +1,53 @@
+package iching
+
+// Line is a single hexagram line, valued as in traditional casting
+type Line int
+
+// Line values, named after the four kinds of line a cast can produce
+const (
+	OldYin    Line = 6
+	YoungYang Line = 7
+	YoungYin  Line = 8
+	OldYang   Line = 9
+)
+
+// Valid reports whether l is one of the four line values
+func (l Line) Valid() bool {
+	return l >= OldYin && l <= OldYang
+}
+
+// IsYang reports whether l is a solid line
+func (l Line) IsYang() bool {
+	return l == YoungYang || l == OldYang
+}
+
+// IsChanging reports whether l is an old line that moves into its opposite
+func (l Line) IsChanging() bool {
+	return l == OldYin || l == OldYang
+}
+
+// Changed returns the line l becomes in the relating figure
+func (l Line) Changed() Line {
+	switch l {
+	case OldYin:
+		return YoungYang
+	case OldYang:
+		return YoungYin
+	}
+	return l
+}
+
+// String draws the line as it is printed in a figure
+func (l Line) String() string {
+	switch l {
+	case OldYin:
+		return "--- X ---"
+	case YoungYang:
+		return "---------"
+	case YoungYin:
+		return "---   ---"
+	case OldYang:
+		return "----O----"
+	}
+	return "    ?    "
+}
@@ -0,0 +1,98 @@
+package iching
+
+import (
+	"testing"
+	"time"
+)
+
+func TestToLunar(t *testing.T) {
+	tests := []struct {
+		date string
+		want LunarDate
+	}{
+		{"1900-01-31", LunarDate{1900, 1, 1, false}},
+		{"1985-02-20", LunarDate{1985, 1, 1, false}},
+		{"2000-02-04", LunarDate{1999, 12, 29, false}},
+		{"2000-02-05", LunarDate{2000, 1, 1, false}},
+		{"2020-05-22", LunarDate{2020, 4, 30, false}},
+		{"2020-05-23", LunarDate{2020, 4, 1, true}},
+		{"2023-03-22", LunarDate{2023, 2, 1, true}},
+		{"2023-09-29", LunarDate{2023, 8, 15, false}},
+		{"2024-02-09", LunarDate{2023, 12, 30, false}},
+		{"2024-02-10", LunarDate{2024, 1, 1, false}},
+		{"2024-06-10", LunarDate{2024, 5, 5, false}},
+		{"2025-10-06", LunarDate{2025, 8, 15, false}},
+		{"2026-02-17", LunarDate{2026, 1, 1, false}},
+	}
+	for _, tt := range tests {
+		date, _ := time.Parse("2006-01-02", tt.date)
+		got, err := ToLunar(date)
+		if err != nil {
+			t.Errorf("ToLunar(%s): %v", tt.date, err)
+		} else if got != tt.want {
+			t.Errorf("ToLunar(%s) = %+v, want %+v", tt.date, got, tt.want)
+		}
+	}
+
+	for _, date := range []string{"1900-01-30", "2101-02-01"} {
+		d, _ := time.Parse("2006-01-02", date)
+		if got, err := ToLunar(d); err == nil {
+			t.Errorf("ToLunar(%s) = %+v, want an error", date, got)
+		}
+	}
+}
+
+func TestPlumBlossom(t *testing.T) {
+	tests := []struct {
+		time string
+		want [6]Line
+	}{
+		// lunar 2023 (rabbit, 4) month 12 day 30, hour Wu (7): Kan over
+		// Xun, moving line 5
+		{"2024-02-09 12:00", [6]Line{8, 7, 7, 8, 9, 8}},
+		// lunar 2024 (dragon, 5) month 1 day 1, hour Wu (7): Gen over
+		// Kan, moving line 2
+		{"2024-02-10 12:00", [6]Line{8, 9, 8, 8, 8, 7}},
+	}
+	for _, tt := range tests {
+		when, _ := time.Parse("2006-01-02 15:04", tt.time)
+		if got := (PlumBlossom{Time: when}).Cast(nil); got != tt.want {
+			t.Errorf("PlumBlossom{%s}.Cast() = %v, want %v", tt.time, got, tt.want)
+		}
+	}
+}
+
+func TestNewPlumBlossom(t *testing.T) {
+	for _, tt := range []struct {
+		time    string
+		wantErr bool
+	}{
+		{"2024-02-10 12:00", false},
+		{"1900-01-31 00:00", false},
+		{"1850-01-01 12:00", true},
+		{"2200-06-01 12:00", true},
+	} {
+		when, _ := time.Parse("2006-01-02 15:04", tt.time)
+		_, err := NewPlumBlossom(when)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NewPlumBlossom(%s) error = %v, want error %v", tt.time, err, tt.wantErr)
+		}
+	}
+}
+
+func TestPlumBlossomOutOfRange(t *testing.T) {
+	tests := []struct {
+		time, nearest string
+	}{
+		{"1850-01-01 12:00", "1900-01-31 12:00"},
+		{"2200-06-01 12:00", "2101-01-28 12:00"},
+	}
+	for _, tt := range tests {
+		when, _ := time.Parse("2006-01-02 15:04", tt.time)
+		nearest, _ := time.Parse("2006-01-02 15:04", tt.nearest)
+		got := (PlumBlossom{Time: when}).Cast(nil)
+		if want := (PlumBlossom{Time: nearest}).Cast(nil); got != want {
+			t.Errorf("PlumBlossom{%s}.Cast() = %v, want %v as for %s", tt.time, got, want, tt.nearest)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package iching
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// numberFigure builds hexagram lines from an upper and a lower trigram
+// number and a moving line number, reducing them mod 8 and mod 6
+func numberFigure(upper, lower, moving int) [6]Line {
+	var lines [6]Line
+
+	upper = reduce(upper, 8)
+	lower = reduce(lower, 8)
+	moving = reduce(moving, 6)
+
+	copy(lines[:3], Trigrams[lower-1].Lines[:])
+	copy(lines[3:], Trigrams[upper-1].Lines[:])
+	if lines[moving-1].IsYang() {
+		lines[moving-1] = OldYang
+	} else {
+		lines[moving-1] = OldYin
+	}
+	return lines
+}
+
+// reduce counts n off by m; a remainder of zero counts as m
+func reduce(n, m int) int {
+	r := n % m
+	if r < 0 {
+		r += m
+	}
+	if r == 0 {
+		return m
+	}
+	return r
+}
+
+// PlumBlossom casts a hexagram from the moment of asking, as in Mei Hua
+// Yi Shu. The year and hour give their earthly branch numbers (Zi = 1),
+// the year changing at the lunar new year; month and day are those of
+// the lunar calendar, a leap month counting as the month it follows.
+// A zero Time means the current time.
+type PlumBlossom struct {
+	Time time.Time
+}
+
+// NewPlumBlossom returns a PlumBlossom casting from t, or an error if
+// t is outside the range ToLunar supports
+func NewPlumBlossom(t time.Time) (PlumBlossom, error) {
+	if _, err := ToLunar(t); err != nil {
+		return PlumBlossom{}, err
+	}
+	return PlumBlossom{Time: t}, nil
+}
+
+// Cast derives the lines from p.Time; rnd is not used. A date outside
+// the range ToLunar supports is taken as the nearest one inside it, use
+// NewPlumBlossom to reject such times instead.
+func (p PlumBlossom) Cast(rnd *rand.Rand) [6]Line {
+	t := p.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	d, err := ToLunar(t)
+	if err != nil {
+		d, _ = ToLunar(clampLunar(t))
+	}
+
+	year := reduce(d.Year-3, 12)
+	hour := (t.Hour()+1)/2%12 + 1
+	sum := year + d.Month + d.Day
+
+	return numberFigure(sum, sum+hour, sum+hour)
+}
+
+// FromNumbers casts a hexagram from two or three numbers that came to
+// mind. The first gives the upper trigram and the second the lower one,
+// counted off by eight; the sum of all of them, counted off by six,
+// gives the moving line.
+func FromNumbers(nums ...int) ([6]Line, error) {
+	if len(nums) < 2 || len(nums) > 3 {
+		return [6]Line{}, errorf("got %d numbers: want two or three", len(nums))
+	}
+	sum := 0
+	for _, n := range nums {
+		if n < 1 {
+			return [6]Line{}, errorf("invalid number %d: want a positive number", n)
+		}
+		sum += n
+	}
+	return numberFigure(nums[0], nums[1], sum), nil
+}
+
+// ParseNumbers casts a hexagram from numbers separated by spaces or
+// commas, like "3 8" or "3,8,5", see FromNumbers
+func ParseNumbers(numbers string) ([6]Line, error) {
+	var nums []int
+
+	fields := strings.FieldsFunc(numbers, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	for _, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return [6]Line{}, errorf("invalid number %q", f)
+		}
+		nums = append(nums, n)
+	}
+	return FromNumbers(nums...)
+}
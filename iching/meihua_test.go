@@ -0,0 +1,33 @@
+package iching
+
+import "testing"
+
+func TestParseNumbers(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    [6]Line
+		wantErr bool
+	}{
+		// Li over Kun, moving line 3+8 = 11 -> 5
+		{in: "3 8", want: [6]Line{8, 8, 8, 7, 6, 7}},
+		// Qian over Qian, moving line 9+17+4 = 30 -> 6
+		{in: "9,17,4", want: [6]Line{7, 7, 7, 7, 7, 9}},
+		// Kun over Zhen, moving line 8+4+1 = 13 -> 1
+		{in: "8 4 1", want: [6]Line{9, 8, 8, 8, 8, 8}},
+		{in: "3", wantErr: true},
+		{in: "1 2 3 4", wantErr: true},
+		{in: "3 0", wantErr: true},
+		{in: "3 -8", wantErr: true},
+		{in: "3 eight", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseNumbers(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseNumbers(%q) error = %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseNumbers(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package iching
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// Caster is a casting method producing hexagram lines, bottom up. All
+// chance must come from rnd, so that a cast can be replayed or drawn
+// from a secure source.
+type Caster interface {
+	Cast(rnd *rand.Rand) [6]Line
+}
+
+// CasterFunc adapts an ordinary function to the Caster interface
+type CasterFunc func(rnd *rand.Rand) [6]Line
+
+// Cast calls f(rnd)
+func (f CasterFunc) Cast(rnd *rand.Rand) [6]Line {
+	return f(rnd)
+}
+
+var (
+	castersMu sync.RWMutex
+	casters   = make(map[string]Caster)
+)
+
+func init() {
+	Register("marbles", CasterFunc(castMarbles))
+	Register("coins", CasterFunc(castCoins))
+	Register("yarrow", CasterFunc(castYarrow))
+	Register("meihua", PlumBlossom{})
+}
+
+// Register makes a casting method available by name. It panics if
+// Register is called twice with the same name or if c is nil.
+func Register(name string, c Caster) {
+	castersMu.Lock()
+	defer castersMu.Unlock()
+	if c == nil {
+		panic("iching: Register caster is nil")
+	}
+	if _, dup := casters[name]; dup {
+		panic("iching: Register called twice for caster " + name)
+	}
+	casters[name] = c
+}
+
+// Lookup returns the casting method registered under name
+func Lookup(name string) (Caster, error) {
+	castersMu.RLock()
+	defer castersMu.RUnlock()
+	c, ok := casters[name]
+	if !ok {
+		return nil, errorf("unknown casting method %q", name)
+	}
+	return c, nil
+}
+
+// Methods returns the names of the registered casting methods, sorted
+func Methods() []string {
+	castersMu.RLock()
+	defer castersMu.RUnlock()
+	names := make([]string, 0, len(casters))
+	for name := range casters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
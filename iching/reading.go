@@ -0,0 +1,47 @@
+package iching
+
+// Reading holds a cast together with its primary and relating figures
+type Reading struct {
+	Lines    [6]Line
+	Primary  Hexagram
+	Relating Hexagram
+	Changing bool
+}
+
+// Resolve looks up the primary figure of the cast lines and, if any line
+// is moving, the relating figure they change into
+func (h Hexagrams) Resolve(lines [6]Line) (Reading, error) {
+	r := Reading{Lines: lines}
+	var relatingShape [6]Line
+
+	for i, l := range lines {
+		relatingShape[i] = l.Changed()
+		if l.IsChanging() {
+			r.Changing = true
+		}
+	}
+
+	var err error
+	r.Primary, err = h.ByLines(lines)
+	if err != nil {
+		return Reading{}, err
+	}
+	if r.Changing {
+		r.Relating, err = h.ByLines(relatingShape)
+		if err != nil {
+			return Reading{}, err
+		}
+	}
+	return r, nil
+}
+
+// ChangingLines returns the positions (1-6, bottom up) of the moving lines
+func (r Reading) ChangingLines() []int {
+	var pos []int
+	for i, l := range r.Lines {
+		if l.IsChanging() {
+			pos = append(pos, i+1)
+		}
+	}
+	return pos
+}
@@ -0,0 +1,39 @@
+package iching
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	h, err := Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		lines             [6]Line
+		primary, relating int
+		changing          []int
+	}{
+		{[6]Line{7, 8, 9, 9, 6, 6}, 55, 42, []int{3, 4, 5, 6}},
+		{[6]Line{7, 7, 7, 7, 7, 7}, 1, 0, nil},
+		{[6]Line{9, 9, 9, 9, 9, 9}, 1, 2, []int{1, 2, 3, 4, 5, 6}},
+		{[6]Line{6, 8, 8, 8, 8, 8}, 2, 24, []int{1}},
+	}
+	for _, tt := range tests {
+		r, err := h.Resolve(tt.lines)
+		if err != nil {
+			t.Errorf("Resolve(%v): %v", tt.lines, err)
+			continue
+		}
+		if r.Primary.ID != tt.primary {
+			t.Errorf("Resolve(%v) primary = %d, want %d", tt.lines, r.Primary.ID, tt.primary)
+		}
+		if r.Changing != (tt.relating != 0) || r.Changing && r.Relating.ID != tt.relating {
+			t.Errorf("Resolve(%v) relating = %d (changing %v), want %d", tt.lines, r.Relating.ID, r.Changing, tt.relating)
+		}
+		if got := r.ChangingLines(); !reflect.DeepEqual(got, tt.changing) {
+			t.Errorf("Resolve(%v) changing lines = %v, want %v", tt.lines, got, tt.changing)
+		}
+	}
+}
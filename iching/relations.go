@@ -0,0 +1,45 @@
+package iching
+
+// NuclearLines returns the lines of the nuclear hexagram, whose lower
+// trigram is formed by lines 2-4 and upper trigram by lines 3-5
+func (hex Hexagram) NuclearLines() [6]Line {
+	l := hex.Lines
+	return [6]Line{l[1], l[2], l[3], l[2], l[3], l[4]}
+}
+
+// Nuclear returns the nuclear (mutual) hexagram of hex
+func (h Hexagrams) Nuclear(hex Hexagram) (Hexagram, error) {
+	return h.ByLines(hex.NuclearLines())
+}
+
+// InverseLines returns the lines of hex turned upside down
+func (hex Hexagram) InverseLines() [6]Line {
+	var lines [6]Line
+	for i, l := range hex.Lines {
+		lines[len(lines)-1-i] = l
+	}
+	return lines
+}
+
+// Inverse returns the inverted (zong) hexagram of hex
+func (h Hexagrams) Inverse(hex Hexagram) (Hexagram, error) {
+	return h.ByLines(hex.InverseLines())
+}
+
+// ComplementLines returns the lines of hex with every line flipped
+func (hex Hexagram) ComplementLines() [6]Line {
+	var lines [6]Line
+	for i, l := range hex.Lines {
+		if l.IsYang() {
+			lines[i] = YoungYin
+		} else {
+			lines[i] = YoungYang
+		}
+	}
+	return lines
+}
+
+// Complement returns the complementary (cuo) hexagram of hex
+func (h Hexagrams) Complement(hex Hexagram) (Hexagram, error) {
+	return h.ByLines(hex.ComplementLines())
+}
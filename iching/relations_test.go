@@ -0,0 +1,43 @@
+package iching
+
+import "testing"
+
+func TestRelations(t *testing.T) {
+	h, err := Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		id                           int
+		nuclear, inverse, complement int
+	}{
+		{1, 1, 1, 2},
+		{3, 23, 4, 50},
+		{11, 54, 12, 12},
+		{20, 23, 19, 34},
+		{55, 28, 56, 59},
+		{63, 64, 64, 64},
+	}
+	for _, tt := range tests {
+		hex, err := h.ByID(tt.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, rel := range []struct {
+			name string
+			find func(Hexagram) (Hexagram, error)
+			want int
+		}{
+			{"Nuclear", h.Nuclear, tt.nuclear},
+			{"Inverse", h.Inverse, tt.inverse},
+			{"Complement", h.Complement, tt.complement},
+		} {
+			got, err := rel.find(hex)
+			if err != nil {
+				t.Errorf("%s(%d): %v", rel.name, tt.id, err)
+			} else if got.ID != rel.want {
+				t.Errorf("%s(%d) = %d, want %d", rel.name, tt.id, got.ID, rel.want)
+			}
+		}
+	}
+}
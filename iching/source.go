@@ -0,0 +1,56 @@
+package iching
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"io"
+	"math/rand"
+)
+
+// ReaderSource is a rand.Source drawing its values from raw bytes, such
+// as crypto/rand or an entropy device like /dev/urandom. Seed has no
+// effect on it.
+type ReaderSource struct {
+	r   io.Reader
+	err error
+}
+
+// NewReaderSource returns a source reading eight bytes from r per value
+func NewReaderSource(r io.Reader) *ReaderSource {
+	return &ReaderSource{r: r}
+}
+
+// NewCryptoSource returns a source backed by crypto/rand
+func NewCryptoSource() *ReaderSource {
+	return NewReaderSource(crand.Reader)
+}
+
+// Uint64 returns the next eight bytes as a number. Once reading has
+// failed it returns zero, which every casting method survives; check
+// Err after casting.
+func (s *ReaderSource) Uint64() uint64 {
+	var b [8]byte
+	if s.err != nil {
+		return 0
+	}
+	if _, err := io.ReadFull(s.r, b[:]); err != nil {
+		s.err = err
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// Int63 returns a non-negative 63-bit number
+func (s *ReaderSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// Seed does nothing, a reader cannot be rewound
+func (s *ReaderSource) Seed(int64) {}
+
+// Err returns the first error met while reading, if any
+func (s *ReaderSource) Err() error {
+	return s.err
+}
+
+var _ rand.Source64 = (*ReaderSource)(nil)
@@ -0,0 +1,45 @@
+package iching
+
+import (
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// zeros is an endless stream of zero bytes, like a stuck entropy device
+type zeros struct{}
+
+func (zeros) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+func TestReaderSourceRunsDry(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       func() io.Reader
+		wantErr error
+	}{
+		{"empty", func() io.Reader { return strings.NewReader("") }, io.EOF},
+		{"short", func() io.Reader { return strings.NewReader("1234") }, io.ErrUnexpectedEOF},
+		{"zeros", func() io.Reader { return zeros{} }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"yarrow", "coins", "marbles"} {
+				c, err := Lookup(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				src := NewReaderSource(tt.r())
+				c.Cast(rand.New(src))
+				if err := src.Err(); err != tt.wantErr {
+					t.Errorf("%s: Err() = %v, want %v", name, err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
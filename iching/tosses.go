@@ -0,0 +1,49 @@
+package iching
+
+import (
+	"strings"
+	"unicode"
+)
+
+// TossLine converts three physical coin tosses, given as h (heads) or
+// t (tails) like "hht", into a line. Heads count 3 and tails 2.
+func TossLine(toss string) (Line, error) {
+	toss = strings.ToLower(strings.TrimSpace(toss))
+	if len(toss) != 3 {
+		return 0, errorf("invalid toss %q: want three h or t characters", toss)
+	}
+	sum := 0
+	for _, c := range toss {
+		switch c {
+		case 'h':
+			sum += 3
+		case 't':
+			sum += 2
+		default:
+			return 0, errorf("invalid toss %q: want three h or t characters", toss)
+		}
+	}
+	return Line(sum), nil
+}
+
+// ParseTosses builds hexagram lines from six coin tosses separated by
+// spaces or commas, like "hht tth hhh htt ttt hth", starting from the
+// bottom up
+func ParseTosses(tosses string) ([6]Line, error) {
+	var lines [6]Line
+
+	fields := strings.FieldsFunc(tosses, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	if len(fields) != len(lines) {
+		return lines, errorf("invalid tosses %q: want six groups of three coins", tosses)
+	}
+	for i, f := range fields {
+		l, err := TossLine(f)
+		if err != nil {
+			return lines, errorf("line %d: %v", i+1, err)
+		}
+		lines[i] = l
+	}
+	return lines, nil
+}
@@ -0,0 +1,29 @@
+package iching
+
+import "testing"
+
+func TestParseTosses(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    [6]Line
+		wantErr bool
+	}{
+		{in: "hht tth hhh htt ttt hth", want: [6]Line{8, 7, 9, 7, 6, 8}},
+		{in: "HHT,tth, hhh  htt\tttt hth", want: [6]Line{8, 7, 9, 7, 6, 8}},
+		{in: "hht tth hhh htt ttt", wantErr: true},
+		{in: "hht tth hhh htt ttt hth hhh", wantErr: true},
+		{in: "hht tth hhh htt ttt hx", wantErr: true},
+		{in: "hht tth hhh htt ttt hthh", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTosses(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTosses(%q) error = %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseTosses(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
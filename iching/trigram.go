@@ -0,0 +1,48 @@
+package iching
+
+// Trigram is one of the eight figures of three lines a hexagram is
+// built from
+type Trigram struct {
+	Name      string
+	Chinese   string
+	Image     string
+	Attribute string
+	Family    string
+	Lines     [3]Line
+}
+
+// Trigrams holds the eight trigrams in Early Heaven order, numbered 1-8
+// as in Mei Hua Yi Shu, with lines bottom up
+var Trigrams = [8]Trigram{
+	{"Qian", "乾", "Heaven", "Strong", "Father", [3]Line{YoungYang, YoungYang, YoungYang}},
+	{"Dui", "兌", "Lake", "Joyous", "Youngest daughter", [3]Line{YoungYang, YoungYang, YoungYin}},
+	{"Li", "離", "Fire", "Clinging", "Middle daughter", [3]Line{YoungYang, YoungYin, YoungYang}},
+	{"Zhen", "震", "Thunder", "Arousing", "Eldest son", [3]Line{YoungYang, YoungYin, YoungYin}},
+	{"Xun", "巽", "Wind", "Penetrating", "Eldest daughter", [3]Line{YoungYin, YoungYang, YoungYang}},
+	{"Kan", "坎", "Water", "Dangerous", "Middle son", [3]Line{YoungYin, YoungYang, YoungYin}},
+	{"Gen", "艮", "Mountain", "Still", "Youngest son", [3]Line{YoungYin, YoungYin, YoungYang}},
+	{"Kun", "坤", "Earth", "Yielding", "Mother", [3]Line{YoungYin, YoungYin, YoungYin}},
+}
+
+// TrigramByLines returns the trigram drawn with the given lines, bottom
+// up. Moving lines are matched as they stand.
+func TrigramByLines(lines [3]Line) Trigram {
+	for _, t := range Trigrams {
+		if t.Lines[0].IsYang() == lines[0].IsYang() &&
+			t.Lines[1].IsYang() == lines[1].IsYang() &&
+			t.Lines[2].IsYang() == lines[2].IsYang() {
+			return t
+		}
+	}
+	return Trigram{}
+}
+
+// Lower returns the trigram formed by the bottom three lines
+func (hex Hexagram) Lower() Trigram {
+	return TrigramByLines([3]Line{hex.Lines[0], hex.Lines[1], hex.Lines[2]})
+}
+
+// Upper returns the trigram formed by the top three lines
+func (hex Hexagram) Upper() Trigram {
+	return TrigramByLines([3]Line{hex.Lines[3], hex.Lines[4], hex.Lines[5]})
+}
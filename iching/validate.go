@@ -0,0 +1,158 @@
+package iching
+
+import (
+	"strings"
+)
+
+// kingWen holds the upper and lower trigram numbers (see Trigrams) of
+// each hexagram in King Wen order
+var kingWen = [64][2]int{
+	{1, 1}, // 1 Heaven over Heaven
+	{8, 8}, // 2 Earth over Earth
+	{6, 4}, // 3 Water over Thunder
+	{7, 6}, // 4 Mountain over Water
+	{6, 1}, // 5 Water over Heaven
+	{1, 6}, // 6 Heaven over Water
+	{8, 6}, // 7 Earth over Water
+	{6, 8}, // 8 Water over Earth
+	{5, 1}, // 9 Wind over Heaven
+	{1, 2}, // 10 Heaven over Lake
+	{8, 1}, // 11 Earth over Heaven
+	{1, 8}, // 12 Heaven over Earth
+	{1, 3}, // 13 Heaven over Fire
+	{3, 1}, // 14 Fire over Heaven
+	{8, 7}, // 15 Earth over Mountain
+	{4, 8}, // 16 Thunder over Earth
+	{2, 4}, // 17 Lake over Thunder
+	{7, 5}, // 18 Mountain over Wind
+	{8, 2}, // 19 Earth over Lake
+	{5, 8}, // 20 Wind over Earth
+	{3, 4}, // 21 Fire over Thunder
+	{7, 3}, // 22 Mountain over Fire
+	{7, 8}, // 23 Mountain over Earth
+	{8, 4}, // 24 Earth over Thunder
+	{1, 4}, // 25 Heaven over Thunder
+	{7, 1}, // 26 Mountain over Heaven
+	{7, 4}, // 27 Mountain over Thunder
+	{2, 5}, // 28 Lake over Wind
+	{6, 6}, // 29 Water over Water
+	{3, 3}, // 30 Fire over Fire
+	{2, 7}, // 31 Lake over Mountain
+	{4, 5}, // 32 Thunder over Wind
+	{1, 7}, // 33 Heaven over Mountain
+	{4, 1}, // 34 Thunder over Heaven
+	{3, 8}, // 35 Fire over Earth
+	{8, 3}, // 36 Earth over Fire
+	{5, 3}, // 37 Wind over Fire
+	{3, 2}, // 38 Fire over Lake
+	{6, 7}, // 39 Water over Mountain
+	{4, 6}, // 40 Thunder over Water
+	{7, 2}, // 41 Mountain over Lake
+	{5, 4}, // 42 Wind over Thunder
+	{2, 1}, // 43 Lake over Heaven
+	{1, 5}, // 44 Heaven over Wind
+	{2, 8}, // 45 Lake over Earth
+	{8, 5}, // 46 Earth over Wind
+	{2, 6}, // 47 Lake over Water
+	{6, 5}, // 48 Water over Wind
+	{2, 3}, // 49 Lake over Fire
+	{3, 5}, // 50 Fire over Wind
+	{4, 4}, // 51 Thunder over Thunder
+	{7, 7}, // 52 Mountain over Mountain
+	{5, 7}, // 53 Wind over Mountain
+	{4, 2}, // 54 Thunder over Lake
+	{4, 3}, // 55 Thunder over Fire
+	{3, 7}, // 56 Fire over Mountain
+	{5, 5}, // 57 Wind over Wind
+	{2, 2}, // 58 Lake over Lake
+	{5, 6}, // 59 Wind over Water
+	{6, 2}, // 60 Water over Lake
+	{5, 2}, // 61 Wind over Lake
+	{4, 7}, // 62 Thunder over Mountain
+	{6, 3}, // 63 Water over Fire
+	{3, 6}, // 64 Fire over Water
+}
+
+// ValidationError lists every problem found in a hexagram set, each
+// prefixed with its location in the data
+type ValidationError struct {
+	Problems []error
+}
+
+func (e *ValidationError) Error() string {
+	msg := "invalid hexagram data:"
+	for _, p := range e.Problems {
+		msg += "\n  " + p.Error()
+	}
+	return msg
+}
+
+// Validate checks that h holds the 64 hexagrams once each, with ids
+// 1-64, unique and well formed lines matching the traditional trigrams,
+// and symbols matching their ids. It returns a *ValidationError listing
+// all problems found.
+func (h Hexagrams) Validate() error {
+	var problems []error
+	report := func(i int, hex Hexagram, format string, a ...interface{}) {
+		problems = append(problems, errorf("hexagrams[%d] (id %d): %v", i, hex.ID, errorf(format, a...)))
+	}
+
+	seenID := make(map[int]int)
+	seenLines := make(map[[6]bool]int)
+	for i, hex := range h.Hexagrams {
+		if hex.ID < 1 || hex.ID > 64 {
+			report(i, hex, "id out of range 1-64")
+		} else if j, dup := seenID[hex.ID]; dup {
+			report(i, hex, "duplicate id, also at hexagrams[%d]", j)
+		} else {
+			seenID[hex.ID] = i
+		}
+		if strings.TrimSpace(hex.Name) == "" {
+			report(i, hex, "missing name")
+		}
+
+		malformed := false
+		for n, l := range hex.Lines {
+			if l != YoungYang && l != YoungYin {
+				report(i, hex, "line %d has value %d, want %d or %d", n+1, l, YoungYang, YoungYin)
+				malformed = true
+			}
+		}
+		if malformed {
+			continue
+		}
+
+		var shape [6]bool
+		for n, l := range hex.Lines {
+			shape[n] = l.IsYang()
+		}
+		if j, dup := seenLines[shape]; dup {
+			report(i, hex, "duplicate lines, also at hexagrams[%d] (id %d)", j, h.Hexagrams[j].ID)
+		} else {
+			seenLines[shape] = i
+		}
+
+		if hex.ID >= 1 && hex.ID <= 64 {
+			want := kingWen[hex.ID-1]
+			upper, lower := Trigrams[want[0]-1], Trigrams[want[1]-1]
+			if hex.Upper().Name != upper.Name || hex.Lower().Name != lower.Name {
+				report(i, hex, "trigrams %s over %s, want %s over %s",
+					hex.Upper().Image, hex.Lower().Image, upper.Image, lower.Image)
+			}
+			if hex.Symbol != "" && hex.Symbol != string(rune(0x4DC0+hex.ID-1)) {
+				report(i, hex, "symbol %s, want %c", hex.Symbol, rune(0x4DC0+hex.ID-1))
+			}
+		}
+	}
+
+	for id := 1; id <= 64; id++ {
+		if _, ok := seenID[id]; !ok {
+			problems = append(problems, errorf("missing id %d", id))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package iching
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateBuiltIn(t *testing.T) {
+	for _, name := range Translations() {
+		if _, err := Translation(name); err != nil {
+			t.Errorf("translation %s: %v", name, err)
+		}
+	}
+}
+
+func TestValidateProblems(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(hexagrams []Hexagram) []Hexagram
+		want   []string
+	}{
+		{
+			name:   "missing name",
+			mutate: func(hs []Hexagram) []Hexagram { hs[0].Name = " "; return hs },
+			want:   []string{"hexagrams[0] (id 1): missing name"},
+		},
+		{
+			name:   "line value",
+			mutate: func(hs []Hexagram) []Hexagram { hs[1].Lines[2] = OldYang; return hs },
+			want:   []string{"hexagrams[1] (id 2): line 3 has value 9, want 7 or 8"},
+		},
+		{
+			name:   "id out of range",
+			mutate: func(hs []Hexagram) []Hexagram { hs[63].ID = 65; return hs },
+			want:   []string{"hexagrams[63] (id 65): id out of range 1-64", "missing id 64"},
+		},
+		{
+			name:   "duplicate id",
+			mutate: func(hs []Hexagram) []Hexagram { hs[5].ID = 7; return hs },
+			want: []string{
+				"hexagrams[6] (id 7): duplicate id, also at hexagrams[5]",
+				"hexagrams[5] (id 7): trigrams Heaven over Water, want Earth over Water",
+				"hexagrams[5] (id 7): symbol ䷅, want ䷆",
+				"missing id 6",
+			},
+		},
+		{
+			name:   "duplicate lines",
+			mutate: func(hs []Hexagram) []Hexagram { hs[2].Lines = hs[1].Lines; return hs },
+			want:   []string{"hexagrams[2] (id 3): duplicate lines, also at hexagrams[1] (id 2)"},
+		},
+		{
+			name:   "missing hexagram",
+			mutate: func(hs []Hexagram) []Hexagram { return hs[:63] },
+			want:   []string{"missing id 64"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := Default()
+			if err != nil {
+				t.Fatal(err)
+			}
+			h.Hexagrams = tt.mutate(h.Hexagrams)
+			var verr *ValidationError
+			if err := h.Validate(); !errors.As(err, &verr) {
+				t.Fatalf("Validate() = %v, want a *ValidationError", err)
+			}
+			var problems []string
+			for _, p := range verr.Problems {
+				problems = append(problems, p.Error())
+			}
+			for _, want := range tt.want {
+				found := false
+				for _, p := range problems {
+					found = found || p == want
+				}
+				if !found {
+					t.Errorf("problems %q\nlack %q", strings.Join(problems, "; "), want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tyybbi/cliching/iching"
+)
+
+// writeMarkdown writes a reading for pasting into notes and wikis: a
+// section per figure with the figure in a code block, then its texts
+func writeMarkdown(w io.Writer, doc readingDoc) error {
+	bw := bufio.NewWriter(w)
+	primaryTitle := tr("Primary Figure")
+	if doc.Method == "show" {
+		primaryTitle = ""
+	} else {
+		fmt.Fprintf(bw, "*%s*\n\n", describeReading(doc))
+	}
+	writeMarkdownHexagram(bw, doc.Primary, primaryTitle, doc.Lines)
+	if len(doc.Changing) > 0 {
+		fmt.Fprintf(bw, "### %s\n\n", tr("Changing Lines"))
+		for _, pos := range doc.Changing {
+			if text := lineText(doc.Primary, pos); text != "" {
+				fmt.Fprintf(bw, "- %s\n", fmt.Sprintf(tr("Line %d: %s"), pos, text))
+			} else {
+				fmt.Fprintf(bw, "- %s\n", fmt.Sprintf(tr("Line %d"), pos))
+			}
+		}
+		fmt.Fprintln(bw)
+	}
+	if doc.Method == "show" && len(doc.Primary.Texts) > 0 {
+		fmt.Fprintf(bw, "### %s\n\n", tr("Lines"))
+		for i, text := range doc.Primary.Texts {
+			if text != "" {
+				fmt.Fprintf(bw, "- %s\n", fmt.Sprintf(tr("Line %d: %s"), i+1, text))
+			}
+		}
+		fmt.Fprintln(bw)
+	}
+	if doc.Inverse != nil && doc.Complement != nil {
+		fmt.Fprintf(bw, "- %s: %d. %s\n", tr("Inverse"), doc.Inverse.ID, doc.Inverse.Name)
+		fmt.Fprintf(bw, "- %s: %d. %s\n\n", tr("Complementary"), doc.Complement.ID, doc.Complement.Name)
+	}
+	if doc.Relating != nil {
+		writeMarkdownHexagram(bw, *doc.Relating, tr("Relating Figure"), doc.Relating.Lines)
+	}
+	if doc.Nuclear != nil {
+		writeMarkdownHexagram(bw, *doc.Nuclear, tr("Nuclear Figure"), doc.Nuclear.Lines)
+	}
+	return bw.Flush()
+}
+
+func writeMarkdownHexagram(w io.Writer, hex hexagramDoc, title string, lines []int) {
+	heading := fmt.Sprintf("%d. %s", hex.ID, hex.Name)
+	if title != "" {
+		heading = title + ": " + heading
+	}
+	fmt.Fprintf(w, "## %s\n\n", heading)
+	if hex.Chinese != "" {
+		fmt.Fprintf(w, "%s\n\n", strings.TrimSpace(hex.Symbol+" "+hex.Chinese+" "+hex.Pinyin))
+	}
+	fmt.Fprintln(w, "```")
+	for i := len(lines) - 1; i >= 0; i-- {
+		fmt.Fprintln(w, iching.Line(lines[i]))
+	}
+	fmt.Fprint(w, "```\n\n")
+	if hex.Desc != "" {
+		fmt.Fprintf(w, "%s\n\n", hex.Desc)
+	}
+	if hex.Judgement != "" {
+		fmt.Fprintf(w, "### %s\n\n%s\n\n", tr("Judgement"), hex.Judgement)
+	}
+	if hex.Image != "" {
+		fmt.Fprintf(w, "### %s\n\n%s\n\n", tr("Image"), hex.Image)
+	}
+}
+
+// describeReading sums up how a reading was made, like
+// "marbles, seed 7, 2006-01-02 15:04"
+func describeReading(doc readingDoc) string {
+	parts := []string{doc.Method}
+	if doc.Seed != nil {
+		parts = append(parts, fmt.Sprintf("%s %d", strings.ToLower(tr("Seed")), *doc.Seed))
+	}
+	parts = append(parts, doc.Timestamp.Format("2006-01-02 15:04"))
+	return strings.Join(parts, ", ")
+}
+
+// lineText returns the statement of the line at position pos (1-6), if any
+func lineText(hex hexagramDoc, pos int) string {
+	if pos < 1 || pos > len(hex.Texts) {
+		return ""
+	}
+	return hex.Texts[pos-1]
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tyybbi/cliching/iching"
+)
+
+// catalogs holds the translated user interface messages, keyed by
+// language and then by the English message
+var catalogs = map[string]map[string]string{
+	"fi": {
+		"Use coins method instead of marbles (same as -m coins)": "Käytä kolikoita marmorikuulien sijaan (sama kuin -m coins)",
+		"Casting method: %s (list to show them)":                 "Heittotapa: %s (list näyttää ne)",
+		"Same as -m":                                             "Sama kuin -m",
+		"Enter your own coin tosses: six groups of three h (heads) or t (tails), like \"hht tth hhh htt ttt hth\" (starting from the bottom up)": "Syötä omat kolikonheittosi: kuusi kolmen merkin ryhmää h (kruuna) tai t (klaava), esim. \"hht tth hhh htt ttt hth\" (alhaalta ylöspäin)",
+		"Cast from two or three numbers, like \"3 8\" or \"3,8,5\": upper trigram, lower trigram, and their sum for the moving line":             "Heitä kahdesta tai kolmesta luvusta, esim. \"3 8\" tai \"3,8,5\": ylempi trigrammi, alempi trigrammi ja niiden summa muuttuvaa linjaa varten",
+		"Enter your own coin tosses line by line":                                                                                               "Syötä omat kolikonheittosi linja kerrallaan",
+		"Seed for a reproducible reading (default is taken from the clock)":                                                                     "Siemen toistettavaa heittoa varten (oletuksena kellosta)",
+		"Randomness source: math, crypto or the path of an entropy file like /dev/urandom":                                                      "Satunnaisuuden lähde: math, crypto tai entropiatiedoston polku, esim. /dev/urandom",
+		"Moment to cast from with -m meihua, like \"2006-01-02 15:04\" (default is now)":                                                        "Hetki, josta -m meihua heittää, esim. \"2006-01-02 15:04\" (oletuksena nyt)",
+		"Also show the nuclear hexagram":                                                                                                        "Näytä myös ydinheksagrammi",
+		"Load hexagrams from this JSON file (default is $XDG_CONFIG_HOME/cliching/hexagrams.json if present, else the built-in set)":            "Lataa heksagrammit tästä JSON-tiedostosta (oletuksena $XDG_CONFIG_HOME/cliching/hexagrams.json, jos se on olemassa, muuten sisäänrakennettu)",
+		"Built-in translation to use: %s (list to show them, default %s)":                                                                       "Käytettävä sisäänrakennettu käännös: %s (list näyttää ne, oletus %s)",
+		"With -s, show this translation side by side with the chosen one":                                                                       "Näytä -s:n kanssa tämä käännös valitun rinnalla",
+		"Show Chinese names, pinyin and hexagram symbols":                                                                                       "Näytä kiinankieliset nimet, pinyin ja heksagrammimerkit",
+		"Don't show descriptions":                                                                                                               "Älä näytä kuvauksia",
+		"Show specific hexagram (1-64), its description and related hexagrams":                                                                  "Näytä tietty heksagrammi (1-64), sen kuvaus ja sukulaisheksagrammit",
+		"Find hexagram by its lines: x denotes Yang line, y denotes Yin line, or use line values 6-9 like 789966 (starting from the bottom up)": "Etsi heksagrammi linjojen perusteella: x on yang-linja, y on yin-linja, tai käytä linja-arvoja 6-9, esim. 789966 (alhaalta ylöspäin)",
+		"Language of the user interface and hexagram texts, like fi or de (default from LC_ALL, LC_MESSAGES or LANG)":                           "Käyttöliittymän ja heksagrammitekstien kieli, esim. fi tai de (oletuksena LC_ALL, LC_MESSAGES tai LANG)",
+		"Also draw the reading as an SVG image in this file":                                                                                    "Piirrä heitto myös SVG-kuvana tähän tiedostoon",
+		"Also draw the reading as a PNG image in this file":                                                                                     "Piirrä heitto myös PNG-kuvana tähän tiedostoon",
+		"Width of a line in drawn figures, in pixels":                                                                                           "Piirrettyjen kuvioiden linjan leveys pikseleinä",
+		"Colour of the lines in drawn figures":                                                                                                  "Piirrettyjen kuvioiden linjojen väri",
+		"Background colour of drawn figures":                                                                                                    "Piirrettyjen kuvioiden taustaväri",
+		"Colour of the O and X marks on changing lines in drawn figures":                                                                        "Piirrettyjen kuvioiden muuttuvien linjojen O- ja X-merkkien väri",
+		"size %d is too small, want at least 24":                                                                                                "koko %d on liian pieni, vähintään 24",
+		"size %d is too large, want at most %d":                                                                                                 "koko %d on liian suuri, enintään %d",
+		"invalid colour %q: want a form like #b22222":                                                                                           "virheellinen väri %q: odotettiin muotoa #b22222",
+		"Output format: %s":               "Tulostusmuoto: %s",
+		"unknown output format %q":        "tuntematon tulostusmuoto %q",
+		"Usage of %s:":                    "Käyttö: %s",
+		"Flags:":                          "Valitsimet:",
+		"Primary Figure":                  "Pääkuvio",
+		"Relating Figure":                 "Suhdekuvio",
+		"Nuclear Figure":                  "Ydinkuvio",
+		"Changing Lines":                  "Muuttuvat linjat",
+		"Judgement":                       "Tuomio",
+		"Image":                           "Kuva",
+		"Upper":                           "Ylempi",
+		"Lower":                           "Alempi",
+		"Inverse":                         "Käänteinen",
+		"Complementary":                   "Vastakkainen",
+		"Seed":                            "Siemen",
+		"Line %d: %s":                     "Linja %d: %s",
+		"Line %d":                         "Linja %d",
+		"Lines":                           "Linjat",
+		"Line %d, three coins (h/t): ":    "Linja %d, kolme kolikkoa (h/t): ",
+		"%d hexagrams, no problems found": "%d heksagrammia, ei ongelmia",
+		"invalid time %q: want a form like \"2006-01-02 15:04\"": "virheellinen aika %q: odotettiin muotoa \"2006-01-02 15:04\"",
+		"-time only applies to -m meihua":                        "-time koskee vain valintaa -m meihua",
+		"-seed only applies to the math randomness source":       "-seed koskee vain satunnaisuuden lähdettä math",
+		"reading %s: %v":                                                 "luettaessa %s: %v",
+		"invalid hexagram data:":                                         "virheellinen heksagrammiaineisto:",
+		"hexagrams[%d] (id %d): %v":                                      "hexagrams[%d] (tunnus %d): %v",
+		"id out of range 1-64":                                           "tunnus ei ole väliltä 1-64",
+		"duplicate id, also at hexagrams[%d]":                            "sama tunnus myös kohdassa hexagrams[%d]",
+		"missing name":                                                   "nimi puuttuu",
+		"line %d has value %d, want %d or %d":                            "linjan %d arvo on %d, odotettiin %d tai %d",
+		"duplicate lines, also at hexagrams[%d] (id %d)":                 "samat linjat myös kohdassa hexagrams[%d] (tunnus %d)",
+		"trigrams %s over %s, want %s over %s":                           "trigrammit %s / %s, odotettiin %s / %s",
+		"symbol %s, want %c":                                             "symboli %s, odotettiin %c",
+		"missing id %d":                                                  "tunnus %d puuttuu",
+		"date %s is outside the lunar calendar range 1900-2100":          "päivämäärä %s on kuukalenterin välin 1900-2100 ulkopuolella",
+		"got %d numbers: want two or three":                              "annettiin %d lukua: odotettiin kahta tai kolmea",
+		"invalid lines %q: want six x or y characters or six digits 6-9": "virheelliset linjat %q: odotettiin kuutta merkkiä x tai y tai kuutta numeroa 6-9",
+		"invalid number %d: want a positive number":                      "virheellinen luku %d: odotettiin positiivista lukua",
+		"invalid number %q":                                              "virheellinen luku %q",
+		"invalid toss %q: want three h or t characters":                  "virheellinen heitto %q: odotettiin kolmea merkkiä h tai t",
+		"invalid tosses %q: want six groups of three coins":              "virheelliset heitot %q: odotettiin kuutta kolmen kolikon ryhmää",
+		"line %d: %v":                                                    "linja %d: %v",
+		"no hexagram with id %d":                                         "ei heksagrammia tunnuksella %d",
+		"no hexagram with lines %d":                                      "ei heksagrammia linjoilla %d",
+		"parsing hexagram data: %v":                                      "heksagrammiaineiston jäsennys: %v",
+		"translation %s: %v":                                             "käännös %s: %v",
+		"unknown casting method %q":                                      "tuntematon heittotapa %q",
+		"unknown translation %q":                                         "tuntematon käännös %q",
+		"-seed does not apply to -m meihua":                              "-seed ei koske valintaa -m meihua",
+		"Heaven":                                                         "Taivas",
+		"Lake":                                                           "Järvi",
+		"Fire":                                                           "Tuli",
+		"Thunder":                                                        "Ukkonen",
+		"Wind":                                                           "Tuuli",
+		"Water":                                                          "Vesi",
+		"Mountain":                                                       "Vuori",
+		"Earth":                                                          "Maa",
+		"Strong":                                                         "Luja",
+		"Joyous":                                                         "Iloinen",
+		"Clinging":                                                       "Tarttuva",
+		"Arousing":                                                       "Herättävä",
+		"Penetrating":                                                    "Tunkeutuva",
+		"Dangerous":                                                      "Vaarallinen",
+		"Still":                                                          "Liikkumaton",
+		"Yielding":                                                       "Myöntyvä",
+	},
+	"de": {
+		"Use coins method instead of marbles (same as -m coins)": "Münzen statt Murmeln verwenden (wie -m coins)",
+		"Casting method: %s (list to show them)":                 "Orakelmethode: %s (list zeigt sie an)",
+		"Same as -m":                                             "Wie -m",
+		"Enter your own coin tosses: six groups of three h (heads) or t (tails), like \"hht tth hhh htt ttt hth\" (starting from the bottom up)": "Eigene Münzwürfe eingeben: sechs Gruppen aus drei h (Kopf) oder t (Zahl), etwa \"hht tth hhh htt ttt hth\" (von unten nach oben)",
+		"Cast from two or three numbers, like \"3 8\" or \"3,8,5\": upper trigram, lower trigram, and their sum for the moving line":             "Aus zwei oder drei Zahlen werfen, etwa \"3 8\" oder \"3,8,5\": oberes Trigramm, unteres Trigramm und ihre Summe für die wandelnde Linie",
+		"Enter your own coin tosses line by line":                                                                                               "Eigene Münzwürfe Linie für Linie eingeben",
+		"Seed for a reproducible reading (default is taken from the clock)":                                                                     "Startwert für eine wiederholbare Befragung (Standard ist die Uhrzeit)",
+		"Randomness source: math, crypto or the path of an entropy file like /dev/urandom":                                                      "Zufallsquelle: math, crypto oder der Pfad einer Entropiedatei wie /dev/urandom",
+		"Moment to cast from with -m meihua, like \"2006-01-02 15:04\" (default is now)":                                                        "Zeitpunkt für -m meihua, etwa \"2006-01-02 15:04\" (Standard ist jetzt)",
+		"Also show the nuclear hexagram":                                                                                                        "Auch das Kernhexagramm anzeigen",
+		"Load hexagrams from this JSON file (default is $XDG_CONFIG_HOME/cliching/hexagrams.json if present, else the built-in set)":            "Hexagramme aus dieser JSON-Datei laden (Standard ist $XDG_CONFIG_HOME/cliching/hexagrams.json, falls vorhanden, sonst die eingebauten)",
+		"Built-in translation to use: %s (list to show them, default %s)":                                                                       "Eingebaute Übersetzung: %s (list zeigt sie an, Standard %s)",
+		"With -s, show this translation side by side with the chosen one":                                                                       "Mit -s diese Übersetzung neben der gewählten anzeigen",
+		"Show Chinese names, pinyin and hexagram symbols":                                                                                       "Chinesische Namen, Pinyin und Hexagrammzeichen anzeigen",
+		"Don't show descriptions":                                                                                                               "Keine Beschreibungen anzeigen",
+		"Show specific hexagram (1-64), its description and related hexagrams":                                                                  "Ein bestimmtes Hexagramm (1-64), seine Beschreibung und verwandte Hexagramme anzeigen",
+		"Find hexagram by its lines: x denotes Yang line, y denotes Yin line, or use line values 6-9 like 789966 (starting from the bottom up)": "Hexagramm nach seinen Linien suchen: x steht für eine Yang-Linie, y für eine Yin-Linie, oder Linienwerte 6-9 wie 789966 (von unten nach oben)",
+		"Language of the user interface and hexagram texts, like fi or de (default from LC_ALL, LC_MESSAGES or LANG)":                           "Sprache der Oberfläche und der Hexagrammtexte, etwa fi oder de (Standard aus LC_ALL, LC_MESSAGES oder LANG)",
+		"Also draw the reading as an SVG image in this file":                                                                                    "Die Befragung zusätzlich als SVG-Bild in diese Datei zeichnen",
+		"Also draw the reading as a PNG image in this file":                                                                                     "Die Befragung zusätzlich als PNG-Bild in diese Datei zeichnen",
+		"Width of a line in drawn figures, in pixels":                                                                                           "Breite einer Linie in gezeichneten Figuren, in Pixeln",
+		"Colour of the lines in drawn figures":                                                                                                  "Farbe der Linien in gezeichneten Figuren",
+		"Background colour of drawn figures":                                                                                                    "Hintergrundfarbe gezeichneter Figuren",
+		"Colour of the O and X marks on changing lines in drawn figures":                                                                        "Farbe der O- und X-Zeichen auf wandelnden Linien in gezeichneten Figuren",
+		"size %d is too small, want at least 24":                                                                                                "Größe %d ist zu klein, mindestens 24",
+		"size %d is too large, want at most %d":                                                                                                 "Größe %d ist zu groß, höchstens %d",
+		"invalid colour %q: want a form like #b22222":                                                                                           "ungültige Farbe %q: erwartet wird eine Form wie #b22222",
+		"Output format: %s":               "Ausgabeformat: %s",
+		"unknown output format %q":        "unbekanntes Ausgabeformat %q",
+		"Usage of %s:":                    "Aufruf von %s:",
+		"Flags:":                          "Optionen:",
+		"Primary Figure":                  "Primärfigur",
+		"Relating Figure":                 "Bezugsfigur",
+		"Nuclear Figure":                  "Kernfigur",
+		"Changing Lines":                  "Wandelnde Linien",
+		"Judgement":                       "Urteil",
+		"Image":                           "Bild",
+		"Upper":                           "Oben",
+		"Lower":                           "Unten",
+		"Inverse":                         "Umgekehrt",
+		"Complementary":                   "Komplementär",
+		"Seed":                            "Startwert",
+		"Line %d: %s":                     "Linie %d: %s",
+		"Line %d":                         "Linie %d",
+		"Lines":                           "Linien",
+		"Line %d, three coins (h/t): ":    "Linie %d, drei Münzen (h/t): ",
+		"%d hexagrams, no problems found": "%d Hexagramme, keine Probleme gefunden",
+		"invalid time %q: want a form like \"2006-01-02 15:04\"": "ungültige Zeit %q: erwartet wird eine Form wie \"2006-01-02 15:04\"",
+		"-time only applies to -m meihua":                        "-time gilt nur für -m meihua",
+		"-seed only applies to the math randomness source":       "-seed gilt nur für die Zufallsquelle math",
+		"reading %s: %v":                                                 "beim Lesen von %s: %v",
+		"invalid hexagram data:":                                         "ungültige Hexagrammdaten:",
+		"hexagrams[%d] (id %d): %v":                                      "hexagrams[%d] (ID %d): %v",
+		"id out of range 1-64":                                           "ID nicht im Bereich 1-64",
+		"duplicate id, also at hexagrams[%d]":                            "doppelte ID, auch bei hexagrams[%d]",
+		"missing name":                                                   "Name fehlt",
+		"line %d has value %d, want %d or %d":                            "Linie %d hat den Wert %d, erwartet %d oder %d",
+		"duplicate lines, also at hexagrams[%d] (id %d)":                 "doppelte Linien, auch bei hexagrams[%d] (ID %d)",
+		"trigrams %s over %s, want %s over %s":                           "Trigramme %s über %s, erwartet %s über %s",
+		"symbol %s, want %c":                                             "Symbol %s, erwartet %c",
+		"missing id %d":                                                  "ID %d fehlt",
+		"date %s is outside the lunar calendar range 1900-2100":          "Datum %s liegt außerhalb des Mondkalenderbereichs 1900-2100",
+		"got %d numbers: want two or three":                              "%d Zahlen erhalten: erwartet zwei oder drei",
+		"invalid lines %q: want six x or y characters or six digits 6-9": "ungültige Linien %q: erwartet sechs Zeichen x oder y oder sechs Ziffern 6-9",
+		"invalid number %d: want a positive number":                      "ungültige Zahl %d: erwartet eine positive Zahl",
+		"invalid number %q":                                              "ungültige Zahl %q",
+		"invalid toss %q: want three h or t characters":                  "ungültiger Wurf %q: erwartet drei Zeichen h oder t",
+		"invalid tosses %q: want six groups of three coins":              "ungültige Würfe %q: erwartet sechs Gruppen zu drei Münzen",
+		"line %d: %v":                                                    "Linie %d: %v",
+		"no hexagram with id %d":                                         "kein Hexagramm mit der ID %d",
+		"no hexagram with lines %d":                                      "kein Hexagramm mit den Linien %d",
+		"parsing hexagram data: %v":                                      "Lesen der Hexagrammdaten: %v",
+		"translation %s: %v":                                             "Übersetzung %s: %v",
+		"unknown casting method %q":                                      "unbekannte Orakelmethode %q",
+		"unknown translation %q":                                         "unbekannte Übersetzung %q",
+		"-seed does not apply to -m meihua":                              "-seed gilt nicht für -m meihua",
+		"Heaven":                                                         "Himmel",
+		"Lake":                                                           "See",
+		"Fire":                                                           "Feuer",
+		"Thunder":                                                        "Donner",
+		"Wind":                                                           "Wind",
+		"Water":                                                          "Wasser",
+		"Mountain":                                                       "Berg",
+		"Earth":                                                          "Erde",
+		"Strong":                                                         "Stark",
+		"Joyous":                                                         "Heiter",
+		"Clinging":                                                       "Haftend",
+		"Arousing":                                                       "Erregend",
+		"Penetrating":                                                    "Eindringend",
+		"Dangerous":                                                      "Gefährlich",
+		"Still":                                                          "Ruhend",
+		"Yielding":                                                       "Hingebend",
+	},
+}
+
+// lang is the language of the user interface, "" for English
+var lang string
+
+// tr returns msg in the user interface language, or msg itself when it
+// has no translation
+func tr(msg string) string {
+	if t, ok := catalogs[lang][msg]; ok {
+		return t
+	}
+	return msg
+}
+
+// trError returns the message of err in the user interface language.
+// Errors of the iching package are translated by their format, others,
+// like those of the operating system, are left as they are.
+func trError(err error) string {
+	switch e := err.(type) {
+	case *iching.Error:
+		args := make([]interface{}, len(e.Args))
+		for i, a := range e.Args {
+			if aerr, ok := a.(error); ok {
+				a = trError(aerr)
+			}
+			args[i] = a
+		}
+		return fmt.Sprintf(tr(e.Format), args...)
+	case *iching.ValidationError:
+		msg := tr("invalid hexagram data:")
+		for _, p := range e.Problems {
+			msg += "\n  " + trError(p)
+		}
+		return msg
+	}
+	return err.Error()
+}
+
+// detectLang picks the interface language from a -lang flag in args,
+// else from LC_ALL, LC_MESSAGES or LANG. Values like "fi_FI.UTF-8"
+// give "fi"; C and POSIX locales give English.
+func detectLang(args []string) string {
+	value := ""
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		name := strings.TrimLeft(arg, "-")
+		if name == arg {
+			continue
+		}
+		if strings.HasPrefix(name, "lang=") {
+			value = strings.TrimPrefix(name, "lang=")
+		} else if name == "lang" && i+1 < len(args) {
+			value = args[i+1]
+		}
+	}
+	if value == "" {
+		for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+			if value = os.Getenv(env); value != "" {
+				break
+			}
+		}
+	}
+
+	value = strings.ToLower(value)
+	if i := strings.IndexAny(value, "_.@-"); i >= 0 {
+		value = value[:i]
+	}
+	if value == "c" || value == "posix" || value == "en" {
+		return ""
+	}
+	return value
+}
+
+// padRight pads s with spaces to n characters wide
+func padRight(s string, n int) string {
+	if w := utf8.RuneCountInString(s); w < n {
+		return s + strings.Repeat(" ", n-w)
+	}
+	return s
+}
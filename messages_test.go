@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/tyybbi/cliching/iching"
+)
+
+func TestDetectLang(t *testing.T) {
+	tests := []struct {
+		args []string
+		env  map[string]string
+		want string
+	}{
+		{args: nil, want: ""},
+		{args: []string{"-lang", "fi"}, want: "fi"},
+		{args: []string{"--lang=de_DE.UTF-8"}, want: "de"},
+		{args: []string{"-q", "-lang", "DE"}, want: "de"},
+		{args: []string{"--", "-lang", "fi"}, want: ""},
+		{args: []string{"-lang", "en"}, env: map[string]string{"LANG": "fi_FI.UTF-8"}, want: ""},
+		{env: map[string]string{"LANG": "fi_FI.UTF-8"}, want: "fi"},
+		{env: map[string]string{"LC_ALL": "de_AT@euro", "LANG": "fi_FI"}, want: "de"},
+		{env: map[string]string{"LC_MESSAGES": "de", "LANG": "fi_FI"}, want: "de"},
+		{env: map[string]string{"LANG": "C.UTF-8"}, want: ""},
+		{env: map[string]string{"LC_ALL": "POSIX"}, want: ""},
+	}
+	for _, tt := range tests {
+		for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+			t.Setenv(env, tt.env[env])
+		}
+		if got := detectLang(tt.args); got != tt.want {
+			t.Errorf("detectLang(%q) with %v = %q, want %q", tt.args, tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestTrError(t *testing.T) {
+	defer func(saved string) { lang = saved }(lang)
+	_, tossErr := iching.ParseTosses("hht tth hhh htt ttt hx")
+	_, numErr := iching.ParseNumbers("3")
+	tests := []struct {
+		lang string
+		err  error
+		want string
+	}{
+		{"", tossErr, `line 6: invalid toss "hx": want three h or t characters`},
+		{"fi", tossErr, `linja 6: virheellinen heitto "hx": odotettiin kolmea merkkiä h tai t`},
+		{"de", numErr, "1 Zahlen erhalten: erwartet zwei oder drei"},
+		{"de", &iching.ValidationError{Problems: []error{&iching.Error{Format: "missing id %d", Args: []interface{}{64}}}}, "ungültige Hexagrammdaten:\n  ID 64 fehlt"},
+		{"fi", os.ErrNotExist, os.ErrNotExist.Error()},
+	}
+	for _, tt := range tests {
+		lang = tt.lang
+		if got := trError(tt.err); got != tt.want {
+			t.Errorf("trError(%v) in %q = %q, want %q", tt.err, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestCatalogs(t *testing.T) {
+	verbs := regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+	for name, catalog := range catalogs {
+		for other, otherCatalog := range catalogs {
+			for msg := range catalog {
+				if _, ok := otherCatalog[msg]; !ok {
+					t.Errorf("%q is in the %s catalog but not in %s", msg, name, other)
+				}
+			}
+		}
+		for msg, translated := range catalog {
+			want, got := verbs.FindAllString(msg, -1), verbs.FindAllString(translated, -1)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%s: %q has verbs %v, want %v as in %q", name, translated, got, want, msg)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/tyybbi/cliching/iching"
+)
+
+// hexagramDoc is a hexagram as written in a reading document
+type hexagramDoc struct {
+	ID        int      `json:"id"`
+	Name      string   `json:"name"`
+	Chinese   string   `json:"chinese,omitempty"`
+	Pinyin    string   `json:"pinyin,omitempty"`
+	Symbol    string   `json:"symbol,omitempty"`
+	Lines     []int    `json:"lines"`
+	Desc      string   `json:"desc"`
+	Judgement string   `json:"judgement,omitempty"`
+	Image     string   `json:"image,omitempty"`
+	Texts     []string `json:"texts,omitempty"`
+}
+
+// readingDoc holds everything about a reading or lookup that the -o
+// renderers write out. Its JSON form is the stable -o json document;
+// lines run bottom up and changing holds line positions 1-6.
+type readingDoc struct {
+	Method    string       `json:"method"`
+	Seed      *int64       `json:"seed,omitempty"`
+	Timestamp time.Time    `json:"timestamp"`
+	Lines     []int        `json:"lines"`
+	Changing  []int        `json:"changing"`
+	Primary   hexagramDoc  `json:"primary"`
+	Relating  *hexagramDoc `json:"relating,omitempty"`
+	Nuclear   *hexagramDoc `json:"nuclear,omitempty"`
+
+	// Inverse and Complement are only given for a lookup with -s
+	Inverse    *hexagramDoc `json:"inverse,omitempty"`
+	Complement *hexagramDoc `json:"complement,omitempty"`
+}
+
+// renderers holds the -o output formats other than plain text
+var renderers = map[string]func(io.Writer, readingDoc) error{
+	"html":     writeHTML,
+	"json":     writeJSON,
+	"markdown": writeMarkdown,
+}
+
+func lineValues(lines [6]iching.Line) []int {
+	values := make([]int, len(lines))
+	for i, l := range lines {
+		values[i] = int(l)
+	}
+	return values
+}
+
+// newHexagramDoc copies hex for a document, leaving out line
+// statements when the data has none
+func newHexagramDoc(hex iching.Hexagram) hexagramDoc {
+	doc := hexagramDoc{
+		ID:        hex.ID,
+		Name:      hex.Name,
+		Chinese:   hex.Chinese,
+		Pinyin:    hex.Pinyin,
+		Symbol:    hex.Symbol,
+		Lines:     lineValues(hex.Lines),
+		Desc:      hex.Desc,
+		Judgement: hex.Judgement,
+		Image:     hex.Image,
+	}
+	for _, text := range hex.Texts {
+		if text != "" {
+			doc.Texts = hex.Texts[:]
+			break
+		}
+	}
+	return doc
+}
+
+// newShowDoc describes a single hexagram looked up with -s
+func newShowDoc(hex iching.Hexagram) readingDoc {
+	return readingDoc{
+		Method:    "show",
+		Timestamp: time.Now(),
+		Lines:     lineValues(hex.Lines),
+		Changing:  []int{},
+		Primary:   newHexagramDoc(hex),
+	}
+}
+
+// newReadingDoc describes a reading made with the given method
+func newReadingDoc(method string, reading iching.Reading) readingDoc {
+	doc := readingDoc{
+		Method:    method,
+		Timestamp: time.Now(),
+		Lines:     lineValues(reading.Lines),
+		Changing:  append([]int{}, reading.ChangingLines()...),
+		Primary:   newHexagramDoc(reading.Primary),
+	}
+	if reading.Changing {
+		relating := newHexagramDoc(reading.Relating)
+		doc.Relating = &relating
+	}
+	return doc
+}
+
+func writeJSON(w io.Writer, doc readingDoc) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tyybbi/cliching/iching"
+)
+
+func TestReadingDocJSON(t *testing.T) {
+	h, err := iching.Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, n := iching.YoungYang, iching.YoungYin
+	stable, err := h.Resolve([6]iching.Line{y, y, y, y, y, y})
+	if err != nil {
+		t.Fatal(err)
+	}
+	changing, err := h.Resolve([6]iching.Line{iching.OldYang, y, y, y, y, iching.OldYin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	show, err := h.ByLines([6]iching.Line{n, n, n, n, n, n})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seed := int64(42)
+	tests := []struct {
+		name     string
+		doc      func() readingDoc
+		method   string
+		lines    []interface{}
+		changing []interface{}
+		primary  float64
+		has      []string
+		hasNot   []string
+	}{
+		{
+			name:     "stable",
+			doc:      func() readingDoc { return newReadingDoc("coins", stable) },
+			method:   "coins",
+			lines:    []interface{}{7.0, 7.0, 7.0, 7.0, 7.0, 7.0},
+			changing: []interface{}{},
+			primary:  1,
+			hasNot:   []string{"seed", "relating", "nuclear", "inverse", "complement"},
+		},
+		{
+			name: "changing with seed",
+			doc: func() readingDoc {
+				doc := newReadingDoc("yarrow", changing)
+				doc.Seed = &seed
+				return doc
+			},
+			method:   "yarrow",
+			lines:    []interface{}{9.0, 7.0, 7.0, 7.0, 7.0, 6.0},
+			changing: []interface{}{1.0, 6.0},
+			primary:  43,
+			has:      []string{"seed", "relating"},
+			hasNot:   []string{"nuclear", "inverse", "complement"},
+		},
+		{
+			name: "show",
+			doc: func() readingDoc {
+				doc := newShowDoc(show)
+				addRelations(h, &doc, show)
+				addNuclear(h, &doc, show)
+				return doc
+			},
+			method:   "show",
+			lines:    []interface{}{8.0, 8.0, 8.0, 8.0, 8.0, 8.0},
+			changing: []interface{}{},
+			primary:  2,
+			has:      []string{"nuclear", "inverse", "complement"},
+			hasNot:   []string{"seed", "relating"},
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := writeJSON(&buf, tt.doc()); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		var got map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got["method"] != tt.method {
+			t.Errorf("%s: method = %v, want %q", tt.name, got["method"], tt.method)
+		}
+		if ts, ok := got["timestamp"].(string); !ok {
+			t.Errorf("%s: timestamp = %v, want a string", tt.name, got["timestamp"])
+		} else if _, err := time.Parse(time.RFC3339Nano, ts); err != nil {
+			t.Errorf("%s: timestamp %q: %v", tt.name, ts, err)
+		}
+		if !reflect.DeepEqual(got["lines"], tt.lines) {
+			t.Errorf("%s: lines = %v, want %v", tt.name, got["lines"], tt.lines)
+		}
+		if !reflect.DeepEqual(got["changing"], tt.changing) {
+			t.Errorf("%s: changing = %v, want %v", tt.name, got["changing"], tt.changing)
+		}
+		primary, _ := got["primary"].(map[string]interface{})
+		if primary["id"] != tt.primary {
+			t.Errorf("%s: primary id = %v, want %v", tt.name, primary["id"], tt.primary)
+		}
+		for _, key := range []string{"name", "lines", "desc"} {
+			if _, ok := primary[key]; !ok {
+				t.Errorf("%s: primary has no %q", tt.name, key)
+			}
+		}
+		for _, key := range tt.has {
+			if _, ok := got[key]; !ok {
+				t.Errorf("%s: document has no %q", tt.name, key)
+			}
+		}
+		for _, key := range tt.hasNot {
+			if _, ok := got[key]; ok {
+				t.Errorf("%s: document has %q, want it left out", tt.name, key)
+			}
+		}
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	h, err := iching.Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	y := iching.YoungYang
+	changing, err := h.Resolve([6]iching.Line{iching.OldYang, y, y, y, y, iching.OldYin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	show, err := h.ByID(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seed := int64(7)
+	tests := []struct {
+		name    string
+		doc     func() readingDoc
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "reading",
+			doc: func() readingDoc {
+				doc := newReadingDoc("marbles", changing)
+				doc.Seed = &seed
+				doc.Timestamp = time.Date(2006, 1, 2, 15, 4, 0, 0, time.UTC)
+				return doc
+			},
+			want: []string{
+				"*marbles, seed 7, 2006-01-02 15:04*",
+				"## Primary Figure: 43. ",
+				"### Changing Lines",
+				"## Relating Figure: 44. ",
+				"```\n",
+			},
+			notWant: []string{"- Inverse:"},
+		},
+		{
+			name: "show",
+			doc: func() readingDoc {
+				doc := newShowDoc(show)
+				addRelations(h, &doc, show)
+				return doc
+			},
+			want:    []string{"## 1. ", "- Inverse: 1. ", "- Complementary: 2. "},
+			notWant: []string{"Primary Figure", "Relating Figure", "seed"},
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := writeMarkdown(&buf, tt.doc()); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		got := buf.String()
+		for _, s := range tt.want {
+			if !strings.Contains(got, s) {
+				t.Errorf("%s: output lacks %q:\n%s", tt.name, s, got)
+			}
+		}
+		for _, s := range tt.notWant {
+			if strings.Contains(got, s) {
+				t.Errorf("%s: output has %q:\n%s", tt.name, s, got)
+			}
+		}
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	h, err := iching.Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	show, err := h.ByID(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		doc     func() readingDoc
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "lookup",
+			doc:     func() readingDoc { return newShowDoc(show) },
+			want:    []string{"<!DOCTYPE html>", "</html>"},
+			notWant: []string{`<ul class="relations">`},
+		},
+		{
+			name: "lookup with relations",
+			doc: func() readingDoc {
+				doc := newShowDoc(show)
+				addRelations(h, &doc, show)
+				return doc
+			},
+			want: []string{`<ul class="relations">`, "Inverse: 1. ", "Complementary: 2. "},
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := writeHTML(&buf, tt.doc()); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		got := buf.String()
+		for _, s := range tt.want {
+			if !strings.Contains(got, s) {
+				t.Errorf("%s: output lacks %q", tt.name, s)
+			}
+		}
+		for _, s := range tt.notWant {
+			if strings.Contains(got, s) {
+				t.Errorf("%s: output has %q", tt.name, s)
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+)
+
+// writePNG draws a reading as a PNG image, laid out like the SVG one,
+// with a built-in bitmap font for the captions
+func writePNG(w io.Writer, doc readingDoc, style drawStyle) error {
+	l := layoutReading(doc, style)
+	img := image.NewRGBA(image.Rect(0, 0, l.Width, l.Height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(style.Background), image.Point{}, draw.Src)
+	for _, r := range l.Bars {
+		draw.Draw(img, r, image.NewUniform(style.Colour), image.Point{}, draw.Src)
+	}
+	for _, m := range l.Marks {
+		drawMark(img, m, style.Mark)
+	}
+	for _, a := range l.Arrows {
+		fillPolygon(img, a, style.Colour)
+	}
+	for _, c := range l.Captions {
+		// match the 4/5 of the size per letter the layout allows for
+		scale := c.Size * 4 / 5 / 6
+		if scale < 1 {
+			scale = 1
+		}
+		drawText(img, c.X-textWidth(c.Text, scale)/2, c.Y, scale, c.Text, style.Colour)
+	}
+	return png.Encode(w, img)
+}
+
+// drawMark draws an O or X as strokes a third of its radius thick
+func drawMark(img *image.RGBA, m changeMark, c color.Color) {
+	half := float64(m.R/3+1) / 2
+	for y := m.Y - m.R - 2; y <= m.Y+m.R+2; y++ {
+		for x := m.X - m.R - 2; x <= m.X+m.R+2; x++ {
+			dx, dy := float64(x-m.X)+0.5, float64(y-m.Y)+0.5
+			var on bool
+			if m.Yang {
+				on = math.Abs(math.Hypot(dx, dy)-float64(m.R)) <= half
+			} else {
+				r := float64(m.R) + half
+				on = math.Abs(dx) <= r && math.Abs(dy) <= r &&
+					(math.Abs(dx-dy) <= half*math.Sqrt2 || math.Abs(dx+dy) <= half*math.Sqrt2)
+			}
+			if on {
+				img.Set(x, y, c)
+			}
+		}
+	}
+}
+
+// fillPolygon fills the pixels whose centres fall inside a polygon
+func fillPolygon(img *image.RGBA, points []image.Point, c color.Color) {
+	var bounds image.Rectangle
+	for i, p := range points {
+		r := image.Rectangle{p, p.Add(image.Pt(1, 1))}
+		if i == 0 {
+			bounds = r
+		} else {
+			bounds = bounds.Union(r)
+		}
+	}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			px, py := float64(x)+0.5, float64(y)+0.5
+			inside := false
+			for i, j := 0, len(points)-1; i < len(points); j, i = i, i+1 {
+				a, b := points[i], points[j]
+				if (float64(a.Y) > py) != (float64(b.Y) > py) &&
+					px < float64(b.X-a.X)*(py-float64(a.Y))/float64(b.Y-a.Y)+float64(a.X) {
+					inside = !inside
+				}
+			}
+			if inside {
+				img.Set(x, y, c)
+			}
+		}
+	}
+}
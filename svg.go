@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+)
+
+// writeSVG draws a reading as an SVG image: the primary figure with
+// its changing lines marked O and X and, if any, the relating figure
+func writeSVG(w io.Writer, doc readingDoc, style drawStyle) error {
+	l := layoutReading(doc, style)
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n", l.Width, l.Height, l.Width, l.Height)
+	fmt.Fprintf(bw, "<rect width=\"%d\" height=\"%d\" fill=\"%s\"/>\n", l.Width, l.Height, hexColour(style.Background))
+	for _, r := range l.Bars {
+		fmt.Fprintf(bw, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"%s\"/>\n", r.Min.X, r.Min.Y, r.Dx(), r.Dy(), hexColour(style.Colour))
+	}
+	for _, m := range l.Marks {
+		stroke := fmt.Sprintf("fill=\"none\" stroke=\"%s\" stroke-width=\"%d\"", hexColour(style.Mark), m.R/3+1)
+		if m.Yang {
+			fmt.Fprintf(bw, "<circle cx=\"%d\" cy=\"%d\" r=\"%d\" %s/>\n", m.X, m.Y, m.R, stroke)
+		} else {
+			fmt.Fprintf(bw, "<path d=\"M%d %dL%d %dM%d %dL%d %d\" %s/>\n", m.X-m.R, m.Y-m.R, m.X+m.R, m.Y+m.R, m.X+m.R, m.Y-m.R, m.X-m.R, m.Y+m.R, stroke)
+		}
+	}
+	for _, a := range l.Arrows {
+		points := make([]string, len(a))
+		for i, p := range a {
+			points[i] = fmt.Sprintf("%d,%d", p.X, p.Y)
+		}
+		fmt.Fprintf(bw, "<polygon points=\"%s\" fill=\"%s\"/>\n", strings.Join(points, " "), hexColour(style.Colour))
+	}
+	for _, c := range l.Captions {
+		fmt.Fprintf(bw, "<text x=\"%d\" y=\"%d\" font-family=\"serif\" font-size=\"%d\" text-anchor=\"middle\" fill=\"%s\">", c.X, c.Y, c.Size, hexColour(style.Colour))
+		xml.EscapeText(bw, []byte(c.Text))
+		fmt.Fprintln(bw, "</text>")
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+func hexColour(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}